@@ -1,38 +1,81 @@
 package handlers
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange/binance"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange/bybit"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange/deribit"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange/okx"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/execution"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/halts"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/nlu"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/vault"
 )
 
 var (
 	// blandAPIKey is stored but not used in the simplified web-based flow.
 	blandAPIKey string
-	// sessions safely stores the state of each active conversation.
-	sessions    = make(map[string]*TradingSession)
-	sessionsMux = sync.RWMutex{}
+
+	// sessionStore persists conversation and order state so it survives a
+	// restart and can be shared across backend instances. It's set by
+	// InitializeStore before the server starts handling requests.
+	sessionStore store.Store
+
+	// registry dispatches each supported venue name to its exchange.Exchange
+	// implementation. Entries are built with zero-value credentials, which
+	// is enough for the public market-data calls; authenticated calls go
+	// through authenticatedClient, which builds a client from the
+	// session's vaulted credentials.
+	registry = map[string]exchange.Exchange{
+		"OKX":     okx.New(okx.Credentials{}),
+		"Bybit":   bybit.New(bybit.Credentials{}),
+		"Deribit": deribit.New(deribit.Credentials{}),
+		"Binance": binance.New(binance.Credentials{}),
+	}
+
+	// credVault holds each session's per-exchange API credentials,
+	// encrypted at rest. Set by InitializeVault before the server starts
+	// handling requests.
+	credVault *vault.Vault
+
+	// haltRegistry tracks active trading halts (global/exchange/symbol)
+	// consulted before every order placement. Set by InitializeHalts.
+	haltRegistry *halts.Registry
+
+	// haltBreaker auto-trips halts on sharp price moves or repeated venue
+	// errors. Set by InitializeHalts.
+	haltBreaker *halts.Breaker
+
+	// adminAPIKey gates the /admin/halts endpoints; set by InitializeHalts.
+	// Left empty, the admin API refuses every request.
+	adminAPIKey string
 )
 
 // TradingSession holds all the context for a single conversation.
 type TradingSession struct {
-	CallID     string            `json:"call_id"`
-	State      string            `json:"state"`
-	Exchange   string            `json:"exchange"`
-	Symbol     string            `json:"symbol"`
-	Price      float64           `json:"price"`
-	Quantity   float64           `json:"quantity"`
-	OrderPrice float64           `json:"order_price"`
-	Context    map[string]string `json:"context"`
+	CallID          string            `json:"call_id"`
+	State           string            `json:"state"`
+	Exchange        string            `json:"exchange"`
+	Symbol          string            `json:"symbol"`
+	Side            string            `json:"side"`
+	Price           float64           `json:"price"`
+	Quantity        float64           `json:"quantity"`
+	OrderPrice      float64           `json:"order_price"`
+	ExchangeOrderID string            `json:"exchange_order_id,omitempty"`
+	Context         map[string]string `json:"context"`
 }
 
 // BlandPayload is the structure of the data we expect from the frontend's webhook call.
@@ -41,10 +84,14 @@ type BlandPayload struct {
 	CallID    string `json:"call_id"`
 }
 
-// PriceResponse structures for parsing exchange API data.
-type PriceResponse struct {
-	Symbol string `json:"symbol"`
-	Price  string `json:"price"`
+// CredentialsRequest is the body of POST /credentials: the API key a user
+// wants tied to their session for a specific venue.
+type CredentialsRequest struct {
+	CallID     string `json:"call_id" binding:"required"`
+	Exchange   string `json:"exchange" binding:"required"`
+	APIKey     string `json:"api_key" binding:"required"`
+	APISecret  string `json:"api_secret" binding:"required"`
+	Passphrase string `json:"passphrase"`
 }
 
 // InitializeBland stores the API key globally.
@@ -52,6 +99,28 @@ func InitializeBland(apiKey string) {
 	blandAPIKey = apiKey
 }
 
+// InitializeStore wires the backing session/order store. Must be called
+// once before the server starts handling requests.
+func InitializeStore(s store.Store) {
+	sessionStore = s
+}
+
+// InitializeVault wires the credential vault. Must be called once before
+// the server starts handling requests.
+func InitializeVault(v *vault.Vault) {
+	credVault = v
+}
+
+// InitializeHalts wires the halt registry and its circuit breaker, and
+// the bearer token that gates the /admin/halts endpoints. An empty
+// adminKey disables the admin API entirely. Must be called once before
+// the server starts handling requests.
+func InitializeHalts(r *halts.Registry, b *halts.Breaker, adminKey string) {
+	haltRegistry = r
+	haltBreaker = b
+	adminAPIKey = adminKey
+}
+
 // generateSessionID creates a new unique ID for a web conversation.
 func generateSessionID() string {
 	bytes := make([]byte, 16)
@@ -71,7 +140,11 @@ func StartCall(c *gin.Context) {
 		State:   "greeting",
 		Context: make(map[string]string),
 	}
-	updateSession(session)
+	if err := updateSession(session); err != nil {
+		log.Printf("Failed to save new session %s: %v", callID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start session"})
+		return
+	}
 
 	log.Printf("New web session started with ID: %s", callID)
 
@@ -91,23 +164,218 @@ func HandleBlandWebhook(c *gin.Context) {
 		return
 	}
 
-	session := getOrCreateSession(payload.CallID)
+	session, err := getOrCreateSession(payload.CallID)
+	if err != nil {
+		log.Printf("Failed to load session %s: %v", payload.CallID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"response": "Something went wrong loading your session."})
+		return
+	}
 	utterance := strings.ToLower(strings.TrimSpace(payload.Utterance))
 
 	response := processUserInput(session, utterance)
 
-	updateSession(session)
+	if err := updateSession(session); err != nil {
+		log.Printf("Failed to save session %s: %v", session.CallID, err)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"response": response})
 }
 
+// GetSession handles GET /sessions/:id, returning the persisted
+// conversation state for a call.
+func GetSession(c *gin.Context) {
+	callID := c.Param("id")
+	sess, err := sessionStore.LoadSession(c.Request.Context(), callID)
+	if err == store.ErrSessionNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to load session %s: %v", callID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load session"})
+		return
+	}
+	c.JSON(http.StatusOK, sess)
+}
+
+// ListSessionOrders handles GET /sessions/:id/orders, filtering the order
+// blotter by state and a timestamp range using the query string, the
+// same optional-parameter style used by the exchange subpackages.
+func ListSessionOrders(c *gin.Context) {
+	callID := c.Param("id")
+
+	filter := store.OrderFilter{State: c.Query("state")}
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be RFC3339"})
+			return
+		}
+		filter.From = t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be RFC3339"})
+			return
+		}
+		filter.To = t
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+			return
+		}
+		filter.Limit = n
+	}
+
+	orders, err := sessionStore.ListOrders(c.Request.Context(), callID, filter)
+	if err != nil {
+		log.Printf("Failed to list orders for %s: %v", callID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list orders"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+// RegisterCredentials handles POST /credentials, encrypting and storing a
+// user's per-exchange API key against their session's CallID.
+func RegisterCredentials(c *gin.Context) {
+	var req CredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "call_id, exchange, api_key and api_secret are required"})
+		return
+	}
+
+	if _, ok := registry[req.Exchange]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported exchange: %s", req.Exchange)})
+		return
+	}
+
+	creds := vault.Credentials{APIKey: req.APIKey, APISecret: req.APISecret, Passphrase: req.Passphrase}
+	if err := credVault.Put(req.CallID, req.Exchange, creds); err != nil {
+		log.Printf("Failed to store credentials for %s/%s: %v", req.CallID, req.Exchange, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "stored"})
+}
+
+// AdminHaltRequest is the body of POST /admin/halts.
+type AdminHaltRequest struct {
+	Scope    string `json:"scope" binding:"required"` // "global", "exchange", or "symbol"
+	Exchange string `json:"exchange"`
+	Symbol   string `json:"symbol"`
+	Until    string `json:"until"` // RFC3339; omitted means in effect until manually cleared
+	Reason   string `json:"reason" binding:"required"`
+}
+
+// requireAdmin gates the admin API behind a bearer token matching
+// ADMIN_API_KEY, the same Authorization header convention the frontend
+// already sends for the trading flow. It writes the response and returns
+// false if the request should not proceed.
+func requireAdmin(c *gin.Context) bool {
+	if adminAPIKey == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "admin API is disabled: ADMIN_API_KEY is not set"})
+		return false
+	}
+	if c.GetHeader("Authorization") != "Bearer "+adminAPIKey {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return false
+	}
+	return true
+}
+
+// AdminCreateHalt handles POST /admin/halts, placing a new halt at the
+// requested scope.
+func AdminCreateHalt(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req AdminHaltRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope and reason are required"})
+		return
+	}
+
+	var until time.Time
+	if req.Until != "" {
+		t, err := time.Parse(time.RFC3339, req.Until)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be RFC3339"})
+			return
+		}
+		until = t
+	}
+
+	symbol := req.Symbol
+	if symbol != "" {
+		symbol = parseCurrencyPair(symbol).String()
+	}
+	halt, err := haltRegistry.Place(c.Request.Context(), halts.Scope(req.Scope), req.Exchange, symbol, until, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, halt)
+}
+
+// AdminListHalts handles GET /admin/halts, returning the currently active
+// halt set.
+func AdminListHalts(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"halts": haltRegistry.Active()})
+}
+
+// haltBlockedMessage explains to the user why order placement is
+// currently blocked, including the halt's reason and, if it has one, its
+// expiry.
+func haltBlockedMessage(halt store.Halt) string {
+	if halt.Until.IsZero() {
+		return fmt.Sprintf("Trading is currently halted (%s). It will stay halted until an operator clears it.", halt.Reason)
+	}
+	return fmt.Sprintf("Trading is currently halted (%s). It's expected to resume at %s.", halt.Reason, halt.Until.Format(time.RFC3339))
+}
+
+// authenticatedClient builds a per-venue exchange.Exchange using the
+// session's vaulted API credentials. Sessions with no registered
+// credentials fall back to the simulated-order flow.
+func authenticatedClient(session *TradingSession) (exchange.Exchange, error) {
+	creds, err := credVault.Get(session.CallID, session.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	switch session.Exchange {
+	case "OKX":
+		return okx.New(okx.Credentials{APIKey: creds.APIKey, SecretKey: creds.APISecret, Passphrase: creds.Passphrase}), nil
+	case "Bybit":
+		return bybit.New(bybit.Credentials{APIKey: creds.APIKey, SecretKey: creds.APISecret}), nil
+	case "Deribit":
+		return deribit.New(deribit.Credentials{ClientID: creds.APIKey, ClientSecret: creds.APISecret}), nil
+	case "Binance":
+		return binance.New(binance.Credentials{APIKey: creds.APIKey, SecretKey: creds.APISecret}), nil
+	default:
+		return nil, fmt.Errorf("unsupported exchange: %s", session.Exchange)
+	}
+}
+
 // --- State Management ---
-func getOrCreateSession(callID string) *TradingSession {
-	sessionsMux.Lock()
-	defer sessionsMux.Unlock()
 
-	if session, exists := sessions[callID]; exists {
-		return session
+// getOrCreateSession loads a session from the store, creating and
+// persisting a fresh one if this is the first message for callID.
+func getOrCreateSession(callID string) (*TradingSession, error) {
+	sess, err := sessionStore.LoadSession(context.Background(), callID)
+	if err == nil {
+		return fromStoredSession(sess), nil
+	}
+	if err != store.ErrSessionNotFound {
+		return nil, err
 	}
 
 	session := &TradingSession{
@@ -115,18 +383,64 @@ func getOrCreateSession(callID string) *TradingSession {
 		State:   "greeting",
 		Context: make(map[string]string),
 	}
-	sessions[callID] = session
-	return session
+	if err := updateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func updateSession(session *TradingSession) error {
+	return sessionStore.SaveSession(context.Background(), toStoredSession(session))
+}
+
+// toStoredSession converts a TradingSession to its persisted form,
+// stamping CreatedAt/UpdatedAt. Context is conversational scratch state
+// and isn't persisted.
+func toStoredSession(session *TradingSession) store.Session {
+	now := time.Now()
+	return store.Session{
+		CallID:          session.CallID,
+		State:           session.State,
+		Exchange:        session.Exchange,
+		Symbol:          session.Symbol,
+		Side:            session.Side,
+		Price:           session.Price,
+		Quantity:        session.Quantity,
+		OrderPrice:      session.OrderPrice,
+		ExchangeOrderID: session.ExchangeOrderID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
 }
 
-func updateSession(session *TradingSession) {
-	sessionsMux.Lock()
-	defer sessionsMux.Unlock()
-	sessions[session.CallID] = session
+func fromStoredSession(sess store.Session) *TradingSession {
+	return &TradingSession{
+		CallID:          sess.CallID,
+		State:           sess.State,
+		Exchange:        sess.Exchange,
+		Symbol:          sess.Symbol,
+		Side:            sess.Side,
+		Price:           sess.Price,
+		Quantity:        sess.Quantity,
+		OrderPrice:      sess.OrderPrice,
+		ExchangeOrderID: sess.ExchangeOrderID,
+		Context:         make(map[string]string),
+	}
 }
 
 // --- State Machine Logic ---
 func processUserInput(session *TradingSession, utterance string) string {
+	// A few intents ("cancel my order", "what's my balance") apply
+	// regardless of where the conversation currently is, so they're
+	// checked ahead of the state switch rather than duplicated into every
+	// state's handler.
+	if strings.Contains(utterance, "cancel") && strings.Contains(utterance, "order") {
+		return handleCancelOrderIntent(session)
+	}
+	if strings.Contains(utterance, "balance") {
+		return handleBalanceIntent(session)
+	}
+
 	switch session.State {
 	case "greeting":
 		return handleExchangeSelection(session, utterance)
@@ -140,6 +454,8 @@ func processUserInput(session *TradingSession, utterance string) string {
 		return handleOrderPrice(session, utterance)
 	case "confirming":
 		return handleConfirmation(session, utterance)
+	case "order_working":
+		return handleOrderWorking(session, utterance)
 	default:
 		session.State = "greeting"
 		return "I'm sorry, I seem to have lost track. Let's start over. Which exchange would you like to trade on: OKX, Bybit, Deribit, or Binance?"
@@ -167,32 +483,59 @@ func handleExchangeSelection(session *TradingSession, utterance string) string {
 }
 
 func handleSymbolSelection(session *TradingSession, utterance string) string {
-	potentialSymbol := strings.ToUpper(utterance) // Normalize user input
-	price, err := fetchCurrentPrice(session.Exchange, potentialSymbol)
+	potentialSymbol := strings.ToUpper(strings.TrimSpace(utterance)) // Normalize user input
+
+	venue, ok := registry[session.Exchange]
+	if !ok {
+		log.Printf("No exchange registered for %s", session.Exchange)
+		return "Sorry, that exchange isn't available right now. Please pick another one."
+	}
+
+	pair := parseCurrencyPair(potentialSymbol)
+	ticker, err := venue.GetTicker(pair)
 	if err != nil {
-		log.Printf("Failed to fetch price for %s on %s: %v", potentialSymbol, session.Exchange, err)
-		return fmt.Sprintf("Sorry, I couldn't get the price for %s. Please try a different symbol.", potentialSymbol)
+		log.Printf("Failed to fetch ticker for %s on %s: %v", potentialSymbol, session.Exchange, err)
+		return fmt.Sprintf("Sorry, I couldn't get the price for %s on %s. Please try a different symbol.", potentialSymbol, session.Exchange)
 	}
 
 	session.Symbol = potentialSymbol
-	session.Price = price
+	session.Price = ticker.Last
 	session.State = "symbol_selected"
-	return fmt.Sprintf("The current price for %s on %s is $%.4f. Now, what quantity and price for the order?", potentialSymbol, session.Exchange, price)
+	return fmt.Sprintf("The current price for %s on %s is $%.4f. Now, what quantity and price for the order?", potentialSymbol, session.Exchange, ticker.Last)
 }
 
+// minParseConfidence is the nlu.Result.Confidence below which the FSM
+// asks the user to confirm a number rather than silently accepting it.
+const minParseConfidence = 0.7
+
 func handleOrderDetails(session *TradingSession, utterance string) string {
-	quantity, hasQuantity := extractNumber(utterance, []string{"quantity", "amount", "size"})
-	price, hasPrice := extractNumber(utterance, []string{"price", "at", "for"})
+	quantity, price, hasQuantity, hasPrice := nlu.ExtractOrderDetails(utterance)
+
+	if strings.Contains(utterance, "sell") {
+		session.Side = "sell"
+	} else {
+		session.Side = "buy"
+	}
+
+	if hasQuantity && quantity.Confidence < minParseConfidence {
+		return fmt.Sprintf("Just to confirm, did you mean a quantity of %.4g? Please restate the quantity and price.", quantity.Value)
+	}
+	if hasPrice && price.Confidence < minParseConfidence {
+		return fmt.Sprintf("Just to confirm, did you mean a price of %.4g? Please restate the quantity and price.", price.Value)
+	}
 
 	if hasQuantity {
-		session.Quantity = quantity
+		session.Quantity = quantity.Value
 	}
 	if hasPrice {
-		session.OrderPrice = price
+		session.OrderPrice = price.Value
 	}
 
 	if session.Quantity > 0 && session.OrderPrice > 0 {
 		session.State = "confirming"
+		if warning := priceDeviationWarning(session); warning != "" {
+			return warning + " " + confirmOrder(session)
+		}
 		return confirmOrder(session)
 	} else if session.Quantity > 0 {
 		session.State = "awaiting_price"
@@ -206,30 +549,81 @@ func handleOrderDetails(session *TradingSession, utterance string) string {
 }
 
 func handleQuantity(session *TradingSession, utterance string) string {
-	quantity, hasQuantity := extractNumber(utterance, []string{})
+	quantity, hasQuantity := nlu.ExtractFirst(utterance)
 	if !hasQuantity {
 		return "I didn't catch that. How much do you want to trade?"
 	}
-	session.Quantity = quantity
+	if quantity.Confidence < minParseConfidence {
+		return fmt.Sprintf("Just to confirm, did you mean %.4g? Please restate the quantity.", quantity.Value)
+	}
+	session.Quantity = quantity.Value
 	session.State = "confirming"
 	return confirmOrder(session)
 }
 
+// priceSanityThreshold is how far (as a fraction) a typed price may
+// deviate from the streamed mid-price before we warn the user.
+const priceSanityThreshold = 0.02
+
 func handleOrderPrice(session *TradingSession, utterance string) string {
-	price, hasPrice := extractNumber(utterance, []string{})
+	price, hasPrice := nlu.ExtractFirst(utterance)
 	if !hasPrice {
 		return "Sorry, what was the price?"
 	}
-	session.OrderPrice = price
+	if price.Confidence < minParseConfidence {
+		return fmt.Sprintf("Just to confirm, did you mean %.4g? Please restate the price.", price.Value)
+	}
+	session.OrderPrice = price.Value
 	session.State = "confirming"
+
+	if warning := priceDeviationWarning(session); warning != "" {
+		return warning + " " + confirmOrder(session)
+	}
 	return confirmOrder(session)
 }
 
+// priceDeviationWarning compares the session's typed order price against
+// the live streamed mid-price (populated by an open /ws/quotes
+// connection) and returns a warning if it's off by more than
+// priceSanityThreshold.
+func priceDeviationWarning(session *TradingSession) string {
+	mid, ok := cachedMidPrice(session.Exchange, parseCurrencyPair(session.Symbol))
+	if !ok || mid == 0 {
+		return ""
+	}
+
+	deviation := (session.OrderPrice - mid) / mid
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	if deviation <= priceSanityThreshold {
+		return ""
+	}
+	return fmt.Sprintf("Heads up: $%.4f is %.1f%% away from the current market price of $%.4f.", session.OrderPrice, deviation*100, mid)
+}
+
 func handleConfirmation(session *TradingSession, utterance string) string {
-	if strings.Contains(utterance, "yes") || strings.Contains(utterance, "correct") {
-		session.State = "completed"
-		return "Excellent! Your simulated order has been recorded. Thank you for using GoQuant!"
-	} else if strings.Contains(utterance, "no") || strings.Contains(utterance, "wrong") {
+	mode, duration, wantsSmartExecution := parseExecutionIntent(utterance)
+	wantsPlainOrder := strings.Contains(utterance, "yes") || strings.Contains(utterance, "correct")
+
+	// Both paths below submit to the venue, so both must consult the halt
+	// registry first; the "no, let's correct it" path doesn't touch the
+	// venue and isn't gated.
+	if wantsSmartExecution || wantsPlainOrder {
+		if halt, blocked := haltRegistry.Check(session.Exchange, parseCurrencyPair(session.Symbol).String()); blocked {
+			return haltBlockedMessage(halt)
+		}
+	}
+
+	switch {
+	case wantsSmartExecution:
+		return startSmartExecution(session, mode, duration)
+	case wantsPlainOrder:
+		if autoMode, autoDuration, ok := autoSliceOnSlippage(session); ok {
+			return startSmartExecution(session, autoMode, autoDuration)
+		}
+		return placeConfirmedOrder(session)
+	case strings.Contains(utterance, "no") || strings.Contains(utterance, "wrong"):
 		session.State = "symbol_selected" // Go back to order details
 		session.Quantity = 0
 		session.OrderPrice = 0
@@ -238,64 +632,337 @@ func handleConfirmation(session *TradingSession, utterance string) string {
 	return "Please confirm with 'yes' or 'no'."
 }
 
-func confirmOrder(session *TradingSession) string {
-	return fmt.Sprintf("Got it. To confirm, you want to trade %.4f %s at $%.4f per unit on %s. Is that correct?",
-		session.Quantity, session.Symbol, session.OrderPrice, session.Exchange)
+// placeConfirmedOrder submits the session's order to the venue if the
+// user has registered API credentials, otherwise it falls back to the
+// original simulated-order behavior.
+func placeConfirmedOrder(session *TradingSession) string {
+	client, err := authenticatedClient(session)
+	if err != nil {
+		session.State = "completed"
+		return "Excellent! Your simulated order has been recorded. Thank you for using GoQuant!"
+	}
+
+	pair := parseCurrencyPair(session.Symbol)
+	var order *exchange.Order
+	if session.Side == "sell" {
+		order, err = client.LimitSell(pair, session.OrderPrice, session.Quantity)
+	} else {
+		order, err = client.LimitBuy(pair, session.OrderPrice, session.Quantity)
+	}
+	if err != nil {
+		haltBreaker.ObserveVenueError(session.Exchange, err)
+		log.Printf("Failed to place order for %s on %s: %v", session.CallID, session.Exchange, err)
+		session.State = "completed"
+		return fmt.Sprintf("Sorry, the order was rejected by %s: %v", session.Exchange, err)
+	}
+
+	session.ExchangeOrderID = order.OrderID
+	session.State = "order_working"
+	recordOrderTransition(session, order.OrderID, string(exchange.OrderStatusWorking))
+	return fmt.Sprintf("Order placed on %s, ID %s. It's working. Say 'status' to check it or 'cancel my order' to cancel.", session.Exchange, order.OrderID)
 }
 
-// --- Helper Functions ---
+const (
+	// slippageCheckDepth is how many book levels to pull when estimating
+	// the slippage a plain confirm's market sweep would incur.
+	slippageCheckDepth = 20
+	// slippageAutoSliceThreshold is the fractional slippage above which a
+	// plain "yes" confirmation is routed through smart execution instead
+	// of a single order, since the book is too thin to sweep cleanly.
+	slippageAutoSliceThreshold = 0.005
+	// autoSliceDuration is how long an auto-triggered TWAP works the
+	// order over.
+	autoSliceDuration = 5 * time.Minute
+)
+
+// autoSliceOnSlippage estimates the slippage a straight market sweep of
+// the session's quantity would incur against the venue's current depth.
+// When that exceeds slippageAutoSliceThreshold, it reports the TWAP
+// mode/duration a plain confirmation should be upgraded to instead of
+// submitting a single order into a book too thin to sweep cleanly.
+func autoSliceOnSlippage(session *TradingSession) (execution.Mode, time.Duration, bool) {
+	venue, ok := registry[session.Exchange]
+	if !ok {
+		return "", 0, false
+	}
+	depth, err := venue.GetDepth(slippageCheckDepth, parseCurrencyPair(session.Symbol))
+	if err != nil {
+		return "", 0, false
+	}
+	side := exchange.Buy
+	if session.Side == "sell" {
+		side = exchange.Sell
+	}
+	if execution.EstimateSlippage(depth, side, session.Quantity) < slippageAutoSliceThreshold {
+		return "", 0, false
+	}
+	return execution.ModeTWAP, autoSliceDuration, true
+}
+
+// parseExecutionIntent detects phrasing like "twap over 10 minutes" or
+// "vwap over the last hour" and pulls out the slicing mode and duration.
+// It returns ok=false for plain "yes"/"no" confirmations, which
+// handleConfirmation falls through to handle as before.
+func parseExecutionIntent(utterance string) (execution.Mode, time.Duration, bool) {
+	var mode execution.Mode
+	switch {
+	case strings.Contains(utterance, "vwap"):
+		mode = execution.ModeVWAP
+	case strings.Contains(utterance, "twap"), strings.Contains(utterance, "execute over"):
+		mode = execution.ModeTWAP
+	default:
+		return "", 0, false
+	}
+
+	parsed, ok := nlu.ExtractFirst(utterance)
+	amount := parsed.Value
+	if !ok || amount <= 0 {
+		amount = 10 // "vwap over the last hour" etc., with no number, defaults below
+	}
+	unit := time.Minute
+	if strings.Contains(utterance, "hour") {
+		unit = time.Hour
+		if !ok {
+			amount = 1
+		}
+	}
+
+	return mode, time.Duration(amount * float64(unit)), true
+}
+
+// tickSizeFor estimates a cancel-and-replace drift threshold when the
+// venue doesn't expose its tick size directly, as a small fraction of the
+// order's reference price so requoting scales with the asset's price.
+func tickSizeFor(price float64) float64 {
+	return price * 0.0005
+}
+
+// startSmartExecution works the session's confirmed order as a TWAP/VWAP
+// parent instead of submitting it as a single limit order, slicing it
+// into child orders that the execution.Executor places and requotes over
+// duration. Children are persisted against a synthetic parent order ID so
+// handleOrderWorking can report aggregate progress.
+func startSmartExecution(session *TradingSession, mode execution.Mode, duration time.Duration) string {
+	client, err := authenticatedClient(session)
+	if err != nil {
+		session.State = "completed"
+		return "Smart execution needs API credentials on file. Please register them via the /credentials endpoint first."
+	}
+
+	side := exchange.Buy
+	if session.Side == "sell" {
+		side = exchange.Sell
+	}
+
+	const defaultSlices = 5
+	const defaultDriftTicks = 3
+	parentOrderID := fmt.Sprintf("%s-%s", mode, generateSessionID()[:12])
 
-func extractNumber(text string, keywords []string) (float64, bool) {
-	// A simple number extractor. A real-world app would use a more robust NLP library.
-	words := strings.Fields(strings.ReplaceAll(text, ",", ""))
-	for _, word := range words {
-		cleanWord := strings.Trim(word, ".,!?$")
-		if num, err := strconv.ParseFloat(cleanWord, 64); err == nil {
-			return num, true
+	// VWAP places one child per one-minute kline covering the whole run
+	// (at least defaultSlices of them), so a long-duration run still
+	// samples a representative volume profile.
+	volumeLookback := int(duration / time.Minute)
+	if volumeLookback < defaultSlices {
+		volumeLookback = defaultSlices
+	}
+
+	cfg := execution.Config{
+		ExchangeName:   session.Exchange,
+		Pair:           parseCurrencyPair(session.Symbol),
+		Side:           side,
+		Quantity:       session.Quantity,
+		Mode:           mode,
+		Duration:       duration,
+		Slices:         defaultSlices,
+		TickSize:       tickSizeFor(session.OrderPrice),
+		DriftTicks:     defaultDriftTicks,
+		VolumeLookback: volumeLookback,
+	}
+	executor := execution.New(client, sessionStore, session.CallID, parentOrderID, cfg, haltRegistry, haltBreaker)
+	go func() {
+		if err := executor.Run(context.Background()); err != nil {
+			log.Printf("execution: run for parent %s failed: %v", parentOrderID, err)
+		}
+	}()
+
+	session.ExchangeOrderID = parentOrderID
+	session.State = "order_working"
+	return fmt.Sprintf("Working your order as a %s over %s, parent ID %s. Say 'status' to check progress.", strings.ToUpper(string(mode)), duration, parentOrderID)
+}
+
+// isSmartExecutionParentID reports whether orderID was generated by
+// startSmartExecution, rather than returned by a venue, based on its mode
+// prefix.
+func isSmartExecutionParentID(orderID string) bool {
+	return strings.HasPrefix(orderID, string(execution.ModeTWAP)+"-") || strings.HasPrefix(orderID, string(execution.ModeVWAP)+"-")
+}
+
+// handleSmartExecutionStatus reports the aggregate fill progress of a
+// TWAP/VWAP parent order by summing the child fills the Executor has
+// recorded against it in the store.
+func handleSmartExecutionStatus(session *TradingSession) string {
+	orders, err := sessionStore.ListOrders(context.Background(), session.CallID, store.OrderFilter{ParentOrderID: session.ExchangeOrderID})
+	if err != nil {
+		log.Printf("Failed to list child orders for parent %s: %v", session.ExchangeOrderID, err)
+		return "I couldn't check your execution's progress just now. Say 'status' to try again."
+	}
+	if len(orders) == 0 {
+		return "Your execution is just getting started. Say 'status' in a moment to check progress."
+	}
+
+	var filled float64
+	working := 0
+	for _, o := range orders {
+		if o.State == string(exchange.OrderStatusFilled) {
+			filled += o.Quantity
+		}
+		if o.State == string(exchange.OrderStatusWorking) {
+			working++
 		}
 	}
-	return 0, false
+
+	if working == 0 {
+		session.State = "completed"
+		return fmt.Sprintf("Your execution %s has finished, %.4f %s filled. Thank you for using GoQuant!", session.ExchangeOrderID, filled, session.Symbol)
+	}
+	return fmt.Sprintf("Execution %s in progress: %.4f %s filled so far, %d child order(s) still working.", session.ExchangeOrderID, filled, session.Symbol, working)
 }
 
-func fetchCurrentPrice(exchange, symbol string) (float64, error) {
-	log.Printf("Fetching price for %s on %s", symbol, exchange)
+// recordOrderTransition appends a row to the order history log for the
+// session's currently selected symbol/side/price/quantity moving into
+// the given state.
+func recordOrderTransition(session *TradingSession, orderID, state string) {
+	err := sessionStore.AppendOrder(context.Background(), store.Order{
+		CallID:    session.CallID,
+		Exchange:  session.Exchange,
+		Symbol:    session.Symbol,
+		Side:      session.Side,
+		Price:     session.OrderPrice,
+		Quantity:  session.Quantity,
+		OrderID:   orderID,
+		State:     state,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to record order transition for %s: %v", session.CallID, err)
+	}
+}
+
+// handleOrderWorking polls the venue for the confirmed order's status
+// while the session waits for it to fill or be cancelled.
+func handleOrderWorking(session *TradingSession, utterance string) string {
+	if isSmartExecutionParentID(session.ExchangeOrderID) {
+		return handleSmartExecutionStatus(session)
+	}
+
+	client, err := authenticatedClient(session)
+	if err != nil {
+		session.State = "completed"
+		return "I've lost track of your order's credentials. Please start a new order."
+	}
+
+	pair := parseCurrencyPair(session.Symbol)
+	order, err := client.GetOneOrder(session.ExchangeOrderID, pair)
+	if err != nil {
+		log.Printf("Failed to poll order %s on %s: %v", session.ExchangeOrderID, session.Exchange, err)
+		return "I couldn't check the order's status just now. Say 'status' to try again."
+	}
+
+	switch order.Status {
+	case exchange.OrderStatusFilled:
+		session.State = "completed"
+		recordOrderTransition(session, order.OrderID, string(exchange.OrderStatusFilled))
+		return fmt.Sprintf("Your order %s has filled. Thank you for using GoQuant!", order.OrderID)
+	case exchange.OrderStatusCancelled, exchange.OrderStatusRejected:
+		session.State = "completed"
+		recordOrderTransition(session, order.OrderID, string(order.Status))
+		return fmt.Sprintf("Your order %s was %s.", order.OrderID, order.Status)
+	default:
+		return fmt.Sprintf("Order %s is still working (%.4f of %.4f filled).", order.OrderID, order.DealAmount, order.Quantity)
+	}
+}
 
-	// Simple implementation using CoinGecko API (free tier)
-	// For production, you'd want to use the specific exchange APIs
+// handleCancelOrderIntent cancels the session's currently-working order,
+// if any, regardless of what state the conversation is otherwise in.
+func handleCancelOrderIntent(session *TradingSession) string {
+	if session.State != "order_working" || session.ExchangeOrderID == "" {
+		return "You don't have an open order to cancel right now."
+	}
+	if isSmartExecutionParentID(session.ExchangeOrderID) {
+		return "This order is being worked as a smart execution; cancelling it mid-execution isn't supported yet."
+	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client, err := authenticatedClient(session)
+	if err != nil {
+		return "I've lost track of your order's credentials. Please start a new order."
+	}
 
-	// Convert symbol to CoinGecko format (this is simplified)
-	coinId := strings.ToLower(symbol)
-	if strings.Contains(coinId, "btc") || strings.Contains(coinId, "bitcoin") {
-		coinId = "bitcoin"
-	} else if strings.Contains(coinId, "eth") || strings.Contains(coinId, "ethereum") {
-		coinId = "ethereum"
+	pair := parseCurrencyPair(session.Symbol)
+	if err := client.CancelOrder(session.ExchangeOrderID, pair); err != nil {
+		log.Printf("Failed to cancel order %s on %s: %v", session.ExchangeOrderID, session.Exchange, err)
+		return fmt.Sprintf("Sorry, I couldn't cancel order %s: %v", session.ExchangeOrderID, err)
 	}
 
-	url := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=usd", coinId)
+	session.State = "completed"
+	recordOrderTransition(session, session.ExchangeOrderID, string(exchange.OrderStatusCancelled))
+	return fmt.Sprintf("Order %s has been cancelled.", session.ExchangeOrderID)
+}
 
-	resp, err := client.Get(url)
+// handleBalanceIntent reports the session's account balances on its
+// currently selected exchange, regardless of conversation state.
+func handleBalanceIntent(session *TradingSession) string {
+	client, err := authenticatedClient(session)
 	if err != nil {
-		log.Printf("Error fetching price: %v", err)
-		return 0, fmt.Errorf("failed to fetch price data")
+		return "I don't have API credentials on file for you yet. Register them via the /credentials endpoint first."
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("API returned status: %d", resp.StatusCode)
+	account, err := client.GetAccount()
+	if err != nil {
+		log.Printf("Failed to fetch account for %s on %s: %v", session.CallID, session.Exchange, err)
+		return fmt.Sprintf("Sorry, I couldn't fetch your balance on %s right now.", session.Exchange)
 	}
 
-	var priceData map[string]map[string]float64
-	if err := json.NewDecoder(resp.Body).Decode(&priceData); err != nil {
-		return 0, fmt.Errorf("failed to decode price response")
+	if len(account.Balances) == 0 {
+		return fmt.Sprintf("You don't have any balances on %s.", session.Exchange)
 	}
 
-	if price, exists := priceData[coinId]["usd"]; exists {
-		return price, nil
+	var parts []string
+	for currency, balance := range account.Balances {
+		if balance.Available == 0 && balance.Frozen == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%.4f %s (%.4f available)", balance.Available+balance.Frozen, currency, balance.Available))
+	}
+	return fmt.Sprintf("Your balances on %s: %s", session.Exchange, strings.Join(parts, ", "))
+}
+
+func confirmOrder(session *TradingSession) string {
+	return fmt.Sprintf("Got it. To confirm, you want to trade %.4f %s at $%.4f per unit on %s. Is that correct?",
+		session.Quantity, session.Symbol, session.OrderPrice, session.Exchange)
+}
+
+// --- Helper Functions ---
+
+// knownQuoteCurrencies lists quote assets we recognize when splitting a
+// bare symbol like "BTCUSDT" into a CurrencyPair, longest first so
+// "USDT" is preferred over "USD" or "DT".
+var knownQuoteCurrencies = []string{"USDT", "USDC", "USD"}
+
+// parseCurrencyPair normalizes free-form user input ("BTC-USDT",
+// "BTC/USD", "BTCUSDT", "btc") into an exchange.CurrencyPair. Input with
+// no recognizable quote currency is assumed to be quoted in USD.
+func parseCurrencyPair(symbol string) exchange.CurrencyPair {
+	cleaned := strings.ToUpper(symbol)
+	cleaned = strings.NewReplacer("-", "", "/", "", "_", "").Replace(cleaned)
+
+	for _, quote := range knownQuoteCurrencies {
+		if strings.HasSuffix(cleaned, quote) && len(cleaned) > len(quote) {
+			return exchange.CurrencyPair{
+				Basis:   exchange.Currency(strings.TrimSuffix(cleaned, quote)),
+				Counter: exchange.Currency(quote),
+			}
+		}
 	}
 
-	// Fallback to mock price if not found
-	log.Printf("Price not found for %s, using mock price", symbol)
-	return 65123.45, nil
+	return exchange.CurrencyPair{Basis: exchange.Currency(cleaned), Counter: "USD"}
 }
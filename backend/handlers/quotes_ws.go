@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/wsclient"
+)
+
+// quoteMux dedups upstream exchange WebSocket connections across sessions
+// watching the same exchange+symbol.
+var quoteMux = wsclient.NewMultiplexer(map[string]wsclient.Adapter{
+	"OKX":     wsclient.OKXAdapter{},
+	"Bybit":   wsclient.BybitAdapter{},
+	"Deribit": wsclient.DeribitAdapter{},
+	"Binance": wsclient.BinanceAdapter{},
+})
+
+// quoteCache holds the latest streamed quote per "exchange:pair" key, so
+// the FSM's price sanity-check (handleOrderPrice) can use live streamed
+// data without needing its own subscription. Populated as a side effect
+// of any open /ws/quotes connection for that exchange+symbol.
+var quoteCache sync.Map // string -> wsclient.Quote
+
+var upgrader = websocket.Upgrader{
+	// CORS is already enforced at the gin middleware level for the REST
+	// API; the browser clients connecting here are the same frontend.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// StreamQuotes handles GET /ws/quotes/:call_id, upgrading to a WebSocket
+// and forwarding normalized {bid,ask,last,ts} and {bids,asks} messages for
+// the session's currently selected exchange/symbol.
+func StreamQuotes(c *gin.Context) {
+	callID := c.Param("call_id")
+
+	sess, err := sessionStore.LoadSession(c.Request.Context(), callID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+	if sess.Exchange == "" || sess.Symbol == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session has not yet selected an exchange and symbol"})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade websocket for %s: %v", callID, err)
+		return
+	}
+	defer conn.Close()
+
+	pair := parseCurrencyPair(sess.Symbol)
+	quotes, depths, unsubscribe, err := quoteMux.Subscribe(sess.Exchange, pair)
+	if err != nil {
+		conn.WriteJSON(gin.H{"error": err.Error()})
+		return
+	}
+	defer unsubscribe()
+
+	cacheKey := sess.Exchange + ":" + pair.String()
+
+	// A closed client connection only surfaces on the next write, so a
+	// reader goroutine drains (and discards) incoming frames purely to
+	// notice the close promptly.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case q := <-quotes:
+			quoteCache.Store(cacheKey, q)
+			if haltBreaker != nil {
+				haltBreaker.ObservePrice(sess.Exchange, pair.String(), q.Last, time.Now())
+			}
+			if err := conn.WriteJSON(gin.H{"bid": q.Bid, "ask": q.Ask, "last": q.Last, "ts": q.Timestamp}); err != nil {
+				return
+			}
+		case d := <-depths:
+			if err := conn.WriteJSON(gin.H{"bids": d.Bids, "asks": d.Asks}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// cachedMidPrice returns the last streamed mid-price for exchangeName's
+// feed of pair, if a /ws/quotes connection has ever observed one. The
+// cached quote has no age limit, so it may be stale.
+func cachedMidPrice(exchangeName string, pair exchange.CurrencyPair) (float64, bool) {
+	v, ok := quoteCache.Load(exchangeName + ":" + pair.String())
+	if !ok {
+		return 0, false
+	}
+	q := v.(wsclient.Quote)
+	if q.Bid == 0 || q.Ask == 0 {
+		return 0, false
+	}
+	return (q.Bid + q.Ask) / 2, true
+}
@@ -1,15 +1,50 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/abhinavpinamdar/quantbot-backend/handlers"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/halts"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store/postgres"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store/sqlite"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/vault"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
+const (
+	defaultSessionTTL   = 24 * time.Hour
+	reaperCheckInterval = 10 * time.Minute
+	haltRefreshInterval = 15 * time.Second
+)
+
+// newStore builds the backing session/order store from STORE_DRIVER
+// ("sqlite", the default, or "postgres") and STORE_DSN.
+func newStore() (store.Store, error) {
+	driver := os.Getenv("STORE_DRIVER")
+	dsn := os.Getenv("STORE_DSN")
+
+	switch driver {
+	case "postgres":
+		return postgres.New(dsn)
+	case "", "sqlite":
+		if dsn == "" {
+			dsn = "./quantbot.db"
+		}
+		return sqlite.New(dsn)
+	default:
+		log.Fatalf("Unknown STORE_DRIVER %q (expected sqlite or postgres)", driver)
+		return nil, nil
+	}
+}
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: .env file not found. Relying on system environment variables.")
@@ -20,6 +55,36 @@ func main() {
 		log.Println("Warning: BLAND_API_KEY environment variable is not set.")
 	}
 
+	vaultKey := os.Getenv("VAULT_ENCRYPTION_KEY")
+	if vaultKey == "" {
+		log.Fatal("VAULT_ENCRYPTION_KEY environment variable is not set; refusing to start with a guessable credential-vault key")
+	}
+
+	sessionStore, err := newStore()
+	if err != nil {
+		log.Fatalf("Failed to initialize session store: %v", err)
+	}
+	defer sessionStore.Close()
+
+	credVault := vault.New(sha256.Sum256([]byte(vaultKey)), sessionStore)
+
+	sessionTTL := defaultSessionTTL
+	if raw := os.Getenv("SESSION_TTL_HOURS"); raw != "" {
+		if hours, err := strconv.Atoi(raw); err == nil {
+			sessionTTL = time.Duration(hours) * time.Hour
+		}
+	}
+	stopReaper := store.StartReaper(sessionStore, sessionTTL, reaperCheckInterval)
+	defer stopReaper()
+
+	haltRegistry := halts.NewRegistry(sessionStore)
+	if err := haltRegistry.Refresh(context.Background()); err != nil {
+		log.Printf("Warning: failed to load initial halt set: %v", err)
+	}
+	stopHaltRefresh := haltRegistry.Start(haltRefreshInterval)
+	defer stopHaltRefresh()
+	haltBreaker := halts.NewBreaker(haltRegistry, halts.DefaultBreakerConfig)
+
 	r := gin.Default()
 
 	config := cors.DefaultConfig()
@@ -29,9 +94,18 @@ func main() {
 	r.Use(cors.New(config))
 
 	handlers.InitializeBland(blandAPIKey)
+	handlers.InitializeStore(sessionStore)
+	handlers.InitializeVault(credVault)
+	handlers.InitializeHalts(haltRegistry, haltBreaker, os.Getenv("ADMIN_API_KEY"))
 
 	r.POST("/bland/webhook", handlers.HandleBlandWebhook)
 	r.POST("/start-call", handlers.StartCall)
+	r.POST("/credentials", handlers.RegisterCredentials)
+	r.GET("/sessions/:id", handlers.GetSession)
+	r.GET("/sessions/:id/orders", handlers.ListSessionOrders)
+	r.GET("/ws/quotes/:call_id", handlers.StreamQuotes)
+	r.POST("/admin/halts", handlers.AdminCreateHalt)
+	r.GET("/admin/halts", handlers.AdminListHalts)
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "healthy"})
 	})
@@ -0,0 +1,392 @@
+// Package binance implements the exchange.Exchange interface against
+// Binance's spot REST API. Symbols are normalized to Binance's
+// concatenated upper-case form, e.g. CurrencyPair{BTC, USDT} -> "BTCUSDT".
+package binance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+const baseURL = "https://api.binance.com"
+
+// Credentials is a Binance HMAC API key pair (no passphrase).
+type Credentials struct {
+	APIKey    string
+	SecretKey string
+}
+
+// Binance is a REST client for a single Binance account (or
+// unauthenticated for public-only endpoints with a zero-value Credentials).
+type Binance struct {
+	creds      Credentials
+	httpClient *http.Client
+}
+
+func New(creds Credentials) *Binance {
+	return &Binance{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func toBinanceSymbol(pair exchange.CurrencyPair) string {
+	return strings.ToUpper(pair.ToSymbol(""))
+}
+
+// sign implements Binance's HMAC-SHA256 signed-request convention: the
+// query string is signed as-is and appended as a `signature` param.
+func (b *Binance) sign(query string) string {
+	mac := hmac.New(sha256.New, []byte(b.creds.SecretKey))
+	mac.Write([]byte(query))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *Binance) do(method, path string, values url.Values, signed bool) ([]byte, error) {
+	if signed {
+		values.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		values.Set("signature", b.sign(values.Encode()))
+	}
+
+	reqURL := baseURL + path
+	var req *http.Request
+	var err error
+	if method == http.MethodGet || method == http.MethodDelete {
+		req, err = http.NewRequest(method, reqURL+"?"+values.Encode(), nil)
+	} else {
+		req, err = http.NewRequest(method, reqURL, strings.NewReader(values.Encode()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if method != http.MethodGet && method != http.MethodDelete {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if signed {
+		req.Header.Set("X-MBX-APIKEY", b.creds.APIKey)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("binance: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body := make([]byte, 0)
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			body = append(body, buf[:n]...)
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Msg string `json:"msg"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		err := fmt.Errorf("binance: %s (status %d)", apiErr.Msg, resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return nil, &exchange.StatusError{StatusCode: resp.StatusCode, Err: err}
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+func (b *Binance) GetTicker(pair exchange.CurrencyPair) (*exchange.Ticker, error) {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}}
+	data, err := b.do(http.MethodGet, "/api/v3/ticker/24hr", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var row struct {
+		LastPrice string `json:"lastPrice"`
+		BidPrice  string `json:"bidPrice"`
+		AskPrice  string `json:"askPrice"`
+		HighPrice string `json:"highPrice"`
+		LowPrice  string `json:"lowPrice"`
+		Volume    string `json:"volume"`
+		CloseTime int64  `json:"closeTime"`
+	}
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("binance: decode ticker: %w", err)
+	}
+	return &exchange.Ticker{
+		Pair:      pair,
+		Last:      parseFloat(row.LastPrice),
+		Buy:       parseFloat(row.BidPrice),
+		Sell:      parseFloat(row.AskPrice),
+		High:      parseFloat(row.HighPrice),
+		Low:       parseFloat(row.LowPrice),
+		Volume24h: parseFloat(row.Volume),
+		Timestamp: row.CloseTime,
+	}, nil
+}
+
+func (b *Binance) GetDepth(size int, pair exchange.CurrencyPair) (*exchange.Depth, error) {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}, "limit": {strconv.Itoa(size)}}
+	data, err := b.do(http.MethodGet, "/api/v3/depth", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var row struct {
+		Bids [][]string `json:"bids"`
+		Asks [][]string `json:"asks"`
+	}
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("binance: decode depth: %w", err)
+	}
+	return &exchange.Depth{
+		Pair:      pair,
+		Bids:      toDepthRecords(row.Bids),
+		Asks:      toDepthRecords(row.Asks),
+		Timestamp: time.Now().UnixMilli(),
+	}, nil
+}
+
+func (b *Binance) GetKlineRecords(pair exchange.CurrencyPair, period exchange.KlinePeriod, size int, opts ...exchange.OptionalParameter) ([]exchange.Kline, error) {
+	values := url.Values{
+		"symbol":   {toBinanceSymbol(pair)},
+		"interval": {toBinanceInterval(period)},
+		"limit":    {strconv.Itoa(size)},
+	}
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := b.do(http.MethodGet, "/api/v3/klines", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]interface{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("binance: decode klines: %w", err)
+	}
+
+	klines := make([]exchange.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+		openTime, _ := row[0].(float64)
+		klines = append(klines, exchange.Kline{
+			Pair:      pair,
+			Timestamp: int64(openTime),
+			Open:      parseFloat(row[1].(string)),
+			High:      parseFloat(row[2].(string)),
+			Low:       parseFloat(row[3].(string)),
+			Close:     parseFloat(row[4].(string)),
+			Volume:    parseFloat(row[5].(string)),
+		})
+	}
+	return klines, nil
+}
+
+func (b *Binance) placeOrder(pair exchange.CurrencyPair, side exchange.TradeSide, price, quantity float64) (*exchange.Order, error) {
+	values := url.Values{
+		"symbol":      {toBinanceSymbol(pair)},
+		"side":        {strings.ToUpper(string(side))},
+		"type":        {"LIMIT"},
+		"timeInForce": {"GTC"},
+		"price":       {strconv.FormatFloat(price, 'f', -1, 64)},
+		"quantity":    {strconv.FormatFloat(quantity, 'f', -1, 64)},
+	}
+	data, err := b.do(http.MethodPost, "/api/v3/order", values, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var row struct {
+		OrderID int64 `json:"orderId"`
+	}
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("binance: decode order response: %w", err)
+	}
+
+	return &exchange.Order{
+		OrderID:  strconv.FormatInt(row.OrderID, 10),
+		Pair:     pair,
+		Side:     side,
+		Price:    price,
+		Quantity: quantity,
+		Status:   exchange.OrderStatusWorking,
+	}, nil
+}
+
+func (b *Binance) LimitBuy(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return b.placeOrder(pair, exchange.Buy, price, quantity)
+}
+
+func (b *Binance) LimitSell(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return b.placeOrder(pair, exchange.Sell, price, quantity)
+}
+
+func (b *Binance) CancelOrder(orderID string, pair exchange.CurrencyPair) error {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}, "orderId": {orderID}}
+	_, err := b.do(http.MethodDelete, "/api/v3/order", values, true)
+	return err
+}
+
+func (b *Binance) GetOneOrder(orderID string, pair exchange.CurrencyPair) (*exchange.Order, error) {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}, "orderId": {orderID}}
+	data, err := b.do(http.MethodGet, "/api/v3/order", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var row binanceOrder
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("binance: decode order: %w", err)
+	}
+	return row.toOrder(pair), nil
+}
+
+func (b *Binance) GetUnfinishOrders(pair exchange.CurrencyPair) ([]exchange.Order, error) {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}}
+	data, err := b.do(http.MethodGet, "/api/v3/openOrders", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []binanceOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("binance: decode open orders: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (b *Binance) GetOrderHistorys(pair exchange.CurrencyPair, opts ...exchange.OptionalParameter) ([]exchange.Order, error) {
+	values := url.Values{"symbol": {toBinanceSymbol(pair)}}
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := b.do(http.MethodGet, "/api/v3/allOrders", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []binanceOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("binance: decode order history: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (b *Binance) GetAccount() (*exchange.Account, error) {
+	data, err := b.do(http.MethodGet, "/api/v3/account", url.Values{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var row struct {
+		Balances []struct {
+			Asset  string `json:"asset"`
+			Free   string `json:"free"`
+			Locked string `json:"locked"`
+		} `json:"balances"`
+	}
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("binance: decode account: %w", err)
+	}
+
+	balances := make(map[exchange.Currency]exchange.AccountBalance)
+	for _, bal := range row.Balances {
+		balances[exchange.Currency(bal.Asset)] = exchange.AccountBalance{
+			Available: parseFloat(bal.Free),
+			Frozen:    parseFloat(bal.Locked),
+		}
+	}
+	return &exchange.Account{Balances: balances}, nil
+}
+
+type binanceOrder struct {
+	OrderID     int64  `json:"orderId"`
+	Side        string `json:"side"`
+	Price       string `json:"price"`
+	OrigQty     string `json:"origQty"`
+	ExecutedQty string `json:"executedQty"`
+	Status      string `json:"status"`
+	UpdateTime  int64  `json:"updateTime"`
+}
+
+func (r binanceOrder) toOrder(pair exchange.CurrencyPair) *exchange.Order {
+	return &exchange.Order{
+		OrderID:    strconv.FormatInt(r.OrderID, 10),
+		Pair:       pair,
+		Side:       exchange.TradeSide(strings.ToLower(r.Side)),
+		Price:      parseFloat(r.Price),
+		Quantity:   parseFloat(r.OrigQty),
+		DealAmount: parseFloat(r.ExecutedQty),
+		Status:     toOrderStatus(r.Status),
+		Timestamp:  r.UpdateTime,
+	}
+}
+
+func toOrderStatus(binanceStatus string) exchange.OrderStatus {
+	switch binanceStatus {
+	case "FILLED":
+		return exchange.OrderStatusFilled
+	case "CANCELED", "EXPIRED":
+		return exchange.OrderStatusCancelled
+	case "REJECTED":
+		return exchange.OrderStatusRejected
+	default:
+		return exchange.OrderStatusWorking
+	}
+}
+
+func toBinanceInterval(period exchange.KlinePeriod) string {
+	switch period {
+	case exchange.KlinePeriod1Min:
+		return "1m"
+	case exchange.KlinePeriod5Min:
+		return "5m"
+	case exchange.KlinePeriod15Min:
+		return "15m"
+	case exchange.KlinePeriod1Hour:
+		return "1h"
+	case exchange.KlinePeriod1Day:
+		return "1d"
+	default:
+		return "1m"
+	}
+}
+
+func toDepthRecords(rows [][]string) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{
+			Price:  parseFloat(row[0]),
+			Amount: parseFloat(row[1]),
+		})
+	}
+	return records
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
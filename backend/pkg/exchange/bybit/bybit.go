@@ -0,0 +1,437 @@
+// Package bybit implements the exchange.Exchange interface against
+// Bybit's v5 unified REST API. Symbols are normalized to Bybit's
+// concatenated upper-case form, e.g. CurrencyPair{BTC, USDT} -> "BTCUSDT".
+package bybit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+const baseURL = "https://api.bybit.com"
+
+// Credentials is a Bybit HMAC API key pair (no passphrase).
+type Credentials struct {
+	APIKey    string
+	SecretKey string
+}
+
+type Bybit struct {
+	creds      Credentials
+	httpClient *http.Client
+}
+
+func New(creds Credentials) *Bybit {
+	return &Bybit{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func toBybitSymbol(pair exchange.CurrencyPair) string {
+	return strings.ToUpper(pair.ToSymbol(""))
+}
+
+// valuesToJSON flattens url.Values (built the same way for every request,
+// GET or POST) into the single-valued map Bybit expects for JSON POST
+// bodies.
+func valuesToJSON(values url.Values) map[string]string {
+	out := make(map[string]string, len(values))
+	for k, v := range values {
+		if len(v) > 0 {
+			out[k] = v[0]
+		}
+	}
+	return out
+}
+
+// sign implements Bybit's v5 signing scheme: hmac(secret, timestamp +
+// apiKey + recvWindow + queryStringOrBody).
+func (b *Bybit) sign(timestamp, recvWindow, payload string) string {
+	mac := hmac.New(sha256.New, []byte(b.creds.SecretKey))
+	mac.Write([]byte(timestamp + b.creds.APIKey + recvWindow + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *Bybit) do(method, path string, values url.Values, signed bool) ([]byte, error) {
+	const recvWindow = "5000"
+
+	// Bybit's v5 API takes GET parameters in the query string but POST
+	// parameters (order placement/cancellation) as a JSON body.
+	var req *http.Request
+	var err error
+	var payload string
+	if method == http.MethodGet {
+		payload = values.Encode()
+		req, err = http.NewRequest(method, baseURL+path+"?"+payload, nil)
+	} else {
+		body, marshalErr := json.Marshal(valuesToJSON(values))
+		if marshalErr != nil {
+			return nil, fmt.Errorf("bybit: encode request body: %w", marshalErr)
+		}
+		payload = string(body)
+		req, err = http.NewRequest(method, baseURL+path, strings.NewReader(payload))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if signed {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		req.Header.Set("X-BAPI-API-KEY", b.creds.APIKey)
+		req.Header.Set("X-BAPI-TIMESTAMP", timestamp)
+		req.Header.Set("X-BAPI-RECV-WINDOW", recvWindow)
+		req.Header.Set("X-BAPI-SIGN", b.sign(timestamp, recvWindow, payload))
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bybit: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &exchange.StatusError{StatusCode: resp.StatusCode, Err: fmt.Errorf("bybit: http %d", resp.StatusCode)}
+	}
+
+	var envelope struct {
+		RetCode int             `json:"retCode"`
+		RetMsg  string          `json:"retMsg"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("bybit: decode response: %w", err)
+	}
+	if envelope.RetCode != 0 {
+		return nil, fmt.Errorf("bybit: %s (code %d)", envelope.RetMsg, envelope.RetCode)
+	}
+	return envelope.Result, nil
+}
+
+func (b *Bybit) GetTicker(pair exchange.CurrencyPair) (*exchange.Ticker, error) {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}}
+	data, err := b.do(http.MethodGet, "/v5/market/tickers", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []struct {
+			LastPrice string `json:"lastPrice"`
+			Bid1Price string `json:"bid1Price"`
+			Ask1Price string `json:"ask1Price"`
+			HighPrice string `json:"highPrice24h"`
+			LowPrice  string `json:"lowPrice24h"`
+			Volume    string `json:"volume24h"`
+		} `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode ticker: %w", err)
+	}
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("bybit: no ticker for %s", toBybitSymbol(pair))
+	}
+	t := result.List[0]
+	return &exchange.Ticker{
+		Pair:      pair,
+		Last:      parseFloat(t.LastPrice),
+		Buy:       parseFloat(t.Bid1Price),
+		Sell:      parseFloat(t.Ask1Price),
+		High:      parseFloat(t.HighPrice),
+		Low:       parseFloat(t.LowPrice),
+		Volume24h: parseFloat(t.Volume),
+		Timestamp: time.Now().UnixMilli(),
+	}, nil
+}
+
+func (b *Bybit) GetDepth(size int, pair exchange.CurrencyPair) (*exchange.Depth, error) {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}, "limit": {strconv.Itoa(size)}}
+	data, err := b.do(http.MethodGet, "/v5/market/orderbook", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bids [][]string `json:"b"`
+		Asks [][]string `json:"a"`
+		TS   int64      `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode depth: %w", err)
+	}
+	return &exchange.Depth{
+		Pair:      pair,
+		Bids:      toDepthRecords(result.Bids),
+		Asks:      toDepthRecords(result.Asks),
+		Timestamp: result.TS,
+	}, nil
+}
+
+func (b *Bybit) GetKlineRecords(pair exchange.CurrencyPair, period exchange.KlinePeriod, size int, opts ...exchange.OptionalParameter) ([]exchange.Kline, error) {
+	values := url.Values{
+		"category": {"spot"},
+		"symbol":   {toBybitSymbol(pair)},
+		"interval": {toBybitInterval(period)},
+		"limit":    {strconv.Itoa(size)},
+	}
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := b.do(http.MethodGet, "/v5/market/kline", values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List [][]string `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode klines: %w", err)
+	}
+
+	klines := make([]exchange.Kline, 0, len(result.List))
+	for _, row := range result.List {
+		if len(row) < 6 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(row[0], 10, 64)
+		klines = append(klines, exchange.Kline{
+			Pair:      pair,
+			Timestamp: ts,
+			Open:      parseFloat(row[1]),
+			High:      parseFloat(row[2]),
+			Low:       parseFloat(row[3]),
+			Close:     parseFloat(row[4]),
+			Volume:    parseFloat(row[5]),
+		})
+	}
+	return klines, nil
+}
+
+func (b *Bybit) placeOrder(pair exchange.CurrencyPair, side exchange.TradeSide, price, quantity float64) (*exchange.Order, error) {
+	values := url.Values{
+		"category":  {"spot"},
+		"symbol":    {toBybitSymbol(pair)},
+		"side":      {capitalize(string(side))},
+		"orderType": {"Limit"},
+		"price":     {strconv.FormatFloat(price, 'f', -1, 64)},
+		"qty":       {strconv.FormatFloat(quantity, 'f', -1, 64)},
+	}
+	data, err := b.do(http.MethodPost, "/v5/order/create", values, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		OrderID string `json:"orderId"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode order response: %w", err)
+	}
+
+	return &exchange.Order{
+		OrderID:  result.OrderID,
+		Pair:     pair,
+		Side:     side,
+		Price:    price,
+		Quantity: quantity,
+		Status:   exchange.OrderStatusWorking,
+	}, nil
+}
+
+func (b *Bybit) LimitBuy(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return b.placeOrder(pair, exchange.Buy, price, quantity)
+}
+
+func (b *Bybit) LimitSell(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return b.placeOrder(pair, exchange.Sell, price, quantity)
+}
+
+func (b *Bybit) CancelOrder(orderID string, pair exchange.CurrencyPair) error {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}, "orderId": {orderID}}
+	_, err := b.do(http.MethodPost, "/v5/order/cancel", values, true)
+	return err
+}
+
+func (b *Bybit) GetOneOrder(orderID string, pair exchange.CurrencyPair) (*exchange.Order, error) {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}, "orderId": {orderID}}
+	data, err := b.do(http.MethodGet, "/v5/order/realtime", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		List []bybitOrder `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode order: %w", err)
+	}
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("bybit: order %s not found", orderID)
+	}
+	return result.List[0].toOrder(pair), nil
+}
+
+func (b *Bybit) GetUnfinishOrders(pair exchange.CurrencyPair) ([]exchange.Order, error) {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}}
+	data, err := b.do(http.MethodGet, "/v5/order/realtime", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		List []bybitOrder `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode open orders: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(result.List))
+	for _, r := range result.List {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (b *Bybit) GetOrderHistorys(pair exchange.CurrencyPair, opts ...exchange.OptionalParameter) ([]exchange.Order, error) {
+	values := url.Values{"category": {"spot"}, "symbol": {toBybitSymbol(pair)}}
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := b.do(http.MethodGet, "/v5/order/history", values, true)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		List []bybitOrder `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode order history: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(result.List))
+	for _, r := range result.List {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (b *Bybit) GetAccount() (*exchange.Account, error) {
+	values := url.Values{"accountType": {"UNIFIED"}}
+	data, err := b.do(http.MethodGet, "/v5/account/wallet-balance", values, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []struct {
+			Coin []struct {
+				Coin          string `json:"coin"`
+				WalletBalance string `json:"walletBalance"`
+				Locked        string `json:"locked"`
+			} `json:"coin"`
+		} `json:"list"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("bybit: decode account: %w", err)
+	}
+
+	balances := make(map[exchange.Currency]exchange.AccountBalance)
+	if len(result.List) > 0 {
+		for _, c := range result.List[0].Coin {
+			balances[exchange.Currency(c.Coin)] = exchange.AccountBalance{
+				Available: parseFloat(c.WalletBalance),
+				Frozen:    parseFloat(c.Locked),
+			}
+		}
+	}
+	return &exchange.Account{Balances: balances}, nil
+}
+
+type bybitOrder struct {
+	OrderID     string `json:"orderId"`
+	Side        string `json:"side"`
+	Price       string `json:"price"`
+	Qty         string `json:"qty"`
+	CumExecQty  string `json:"cumExecQty"`
+	OrderStatus string `json:"orderStatus"`
+	UpdatedTime string `json:"updatedTime"`
+}
+
+func (r bybitOrder) toOrder(pair exchange.CurrencyPair) *exchange.Order {
+	ts, _ := strconv.ParseInt(r.UpdatedTime, 10, 64)
+	return &exchange.Order{
+		OrderID:    r.OrderID,
+		Pair:       pair,
+		Side:       exchange.TradeSide(strings.ToLower(r.Side)),
+		Price:      parseFloat(r.Price),
+		Quantity:   parseFloat(r.Qty),
+		DealAmount: parseFloat(r.CumExecQty),
+		Status:     toOrderStatus(r.OrderStatus),
+		Timestamp:  ts,
+	}
+}
+
+func toOrderStatus(bybitStatus string) exchange.OrderStatus {
+	switch bybitStatus {
+	case "Filled":
+		return exchange.OrderStatusFilled
+	case "Cancelled", "Deactivated":
+		return exchange.OrderStatusCancelled
+	case "Rejected":
+		return exchange.OrderStatusRejected
+	default:
+		return exchange.OrderStatusWorking
+	}
+}
+
+func toBybitInterval(period exchange.KlinePeriod) string {
+	switch period {
+	case exchange.KlinePeriod1Min:
+		return "1"
+	case exchange.KlinePeriod5Min:
+		return "5"
+	case exchange.KlinePeriod15Min:
+		return "15"
+	case exchange.KlinePeriod1Hour:
+		return "60"
+	case exchange.KlinePeriod1Day:
+		return "D"
+	default:
+		return "1"
+	}
+}
+
+func toDepthRecords(rows [][]string) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{
+			Price:  parseFloat(row[0]),
+			Amount: parseFloat(row[1]),
+		})
+	}
+	return records
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// capitalize renders Bybit's expected "Buy"/"Sell" casing from our
+// lower-case TradeSide constants.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
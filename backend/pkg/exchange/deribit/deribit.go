@@ -0,0 +1,420 @@
+// Package deribit implements the exchange.Exchange interface against
+// Deribit's v2 JSON-RPC-over-HTTP API. Deribit trades options/futures
+// instruments rather than spot pairs, so a CurrencyPair is normalized to
+// Deribit's perpetual instrument name, e.g. CurrencyPair{BTC, USD} ->
+// "BTC-PERPETUAL".
+package deribit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+const baseURL = "https://www.deribit.com/api/v2"
+
+// Credentials is a Deribit client_credentials API key pair. Deribit does
+// not use a passphrase, so there is no field for one.
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+type Deribit struct {
+	creds       Credentials
+	httpClient  *http.Client
+	accessToken atomic.Value // string
+}
+
+func New(creds Credentials) *Deribit {
+	return &Deribit{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func toDeribitInstrument(pair exchange.CurrencyPair) string {
+	return strings.ToUpper(string(pair.Basis)) + "-PERPETUAL"
+}
+
+type rpcEnvelope struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (d *Deribit) call(method string, params url.Values, authed bool) ([]byte, error) {
+	if authed {
+		if err := d.ensureAuth(); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/"+method+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if authed {
+		req.Header.Set("Authorization", "Bearer "+d.accessToken.Load().(string))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("deribit: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &exchange.StatusError{StatusCode: resp.StatusCode, Err: fmt.Errorf("deribit: http %d", resp.StatusCode)}
+	}
+
+	var envelope rpcEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("deribit: decode response: %w", err)
+	}
+	if envelope.Error != nil {
+		return nil, fmt.Errorf("deribit: %s (code %d)", envelope.Error.Message, envelope.Error.Code)
+	}
+	return envelope.Result, nil
+}
+
+// ensureAuth fetches (and caches) an OAuth-style access token via
+// client_credentials, Deribit's authentication scheme.
+func (d *Deribit) ensureAuth() error {
+	if tok, ok := d.accessToken.Load().(string); ok && tok != "" {
+		return nil
+	}
+
+	params := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {d.creds.ClientID},
+		"client_secret": {d.creds.ClientSecret},
+	}
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/public/auth?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("deribit: auth request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope rpcEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("deribit: decode auth response: %w", err)
+	}
+	if envelope.Error != nil {
+		return fmt.Errorf("deribit: auth failed: %s (code %d)", envelope.Error.Message, envelope.Error.Code)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(envelope.Result, &result); err != nil {
+		return fmt.Errorf("deribit: decode access token: %w", err)
+	}
+	d.accessToken.Store(result.AccessToken)
+	return nil
+}
+
+func (d *Deribit) GetTicker(pair exchange.CurrencyPair) (*exchange.Ticker, error) {
+	params := url.Values{"instrument_name": {toDeribitInstrument(pair)}}
+	data, err := d.call("public/ticker", params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		LastPrice    float64 `json:"last_price"`
+		BestBidPrice float64 `json:"best_bid_price"`
+		BestAskPrice float64 `json:"best_ask_price"`
+		Stats        struct {
+			High   float64 `json:"high"`
+			Low    float64 `json:"low"`
+			Volume float64 `json:"volume"`
+		} `json:"stats"`
+		Timestamp int64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("deribit: decode ticker: %w", err)
+	}
+	return &exchange.Ticker{
+		Pair:      pair,
+		Last:      result.LastPrice,
+		Buy:       result.BestBidPrice,
+		Sell:      result.BestAskPrice,
+		High:      result.Stats.High,
+		Low:       result.Stats.Low,
+		Volume24h: result.Stats.Volume,
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+func (d *Deribit) GetDepth(size int, pair exchange.CurrencyPair) (*exchange.Depth, error) {
+	params := url.Values{
+		"instrument_name": {toDeribitInstrument(pair)},
+		"depth":           {strconv.Itoa(size)},
+	}
+	data, err := d.call("public/get_order_book", params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bids      [][]float64 `json:"bids"`
+		Asks      [][]float64 `json:"asks"`
+		Timestamp int64       `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("deribit: decode depth: %w", err)
+	}
+	return &exchange.Depth{
+		Pair:      pair,
+		Bids:      toDepthRecords(result.Bids),
+		Asks:      toDepthRecords(result.Asks),
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+func (d *Deribit) GetKlineRecords(pair exchange.CurrencyPair, period exchange.KlinePeriod, size int, opts ...exchange.OptionalParameter) ([]exchange.Kline, error) {
+	resolution := toDeribitResolution(period)
+	now := time.Now()
+	params := url.Values{
+		"instrument_name": {toDeribitInstrument(pair)},
+		"resolution":      {resolution},
+		"start_timestamp": {strconv.FormatInt(now.Add(-time.Duration(size)*periodDuration(period)).UnixMilli(), 10)},
+		"end_timestamp":   {strconv.FormatInt(now.UnixMilli(), 10)},
+	}
+	exchange.ApplyOptionalParameters(params, opts...)
+
+	data, err := d.call("public/get_tradingview_chart_data", params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Ticks  []int64   `json:"ticks"`
+		Open   []float64 `json:"open"`
+		High   []float64 `json:"high"`
+		Low    []float64 `json:"low"`
+		Close  []float64 `json:"close"`
+		Volume []float64 `json:"volume"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("deribit: decode klines: %w", err)
+	}
+
+	klines := make([]exchange.Kline, 0, len(result.Ticks))
+	for i := range result.Ticks {
+		klines = append(klines, exchange.Kline{
+			Pair:      pair,
+			Timestamp: result.Ticks[i],
+			Open:      result.Open[i],
+			High:      result.High[i],
+			Low:       result.Low[i],
+			Close:     result.Close[i],
+			Volume:    result.Volume[i],
+		})
+	}
+	return klines, nil
+}
+
+func (d *Deribit) placeOrder(method string, pair exchange.CurrencyPair, side exchange.TradeSide, price, quantity float64) (*exchange.Order, error) {
+	params := url.Values{
+		"instrument_name": {toDeribitInstrument(pair)},
+		"amount":          {strconv.FormatFloat(quantity, 'f', -1, 64)},
+		"type":            {"limit"},
+		"price":           {strconv.FormatFloat(price, 'f', -1, 64)},
+	}
+	data, err := d.call(method, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Order deribitOrder `json:"order"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("deribit: decode order response: %w", err)
+	}
+	order := result.Order.toOrder(pair)
+	order.Side = side
+	return order, nil
+}
+
+func (d *Deribit) LimitBuy(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return d.placeOrder("private/buy", pair, exchange.Buy, price, quantity)
+}
+
+func (d *Deribit) LimitSell(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return d.placeOrder("private/sell", pair, exchange.Sell, price, quantity)
+}
+
+func (d *Deribit) CancelOrder(orderID string, pair exchange.CurrencyPair) error {
+	params := url.Values{"order_id": {orderID}}
+	_, err := d.call("private/cancel", params, true)
+	return err
+}
+
+func (d *Deribit) GetOneOrder(orderID string, pair exchange.CurrencyPair) (*exchange.Order, error) {
+	params := url.Values{"order_id": {orderID}}
+	data, err := d.call("private/get_order_state", params, true)
+	if err != nil {
+		return nil, err
+	}
+	var row deribitOrder
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("deribit: decode order: %w", err)
+	}
+	return row.toOrder(pair), nil
+}
+
+func (d *Deribit) GetUnfinishOrders(pair exchange.CurrencyPair) ([]exchange.Order, error) {
+	params := url.Values{"instrument_name": {toDeribitInstrument(pair)}}
+	data, err := d.call("private/get_open_orders_by_instrument", params, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []deribitOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("deribit: decode open orders: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (d *Deribit) GetOrderHistorys(pair exchange.CurrencyPair, opts ...exchange.OptionalParameter) ([]exchange.Order, error) {
+	params := url.Values{"instrument_name": {toDeribitInstrument(pair)}}
+	exchange.ApplyOptionalParameters(params, opts...)
+
+	data, err := d.call("private/get_order_history_by_instrument", params, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []deribitOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("deribit: decode order history: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (d *Deribit) GetAccount() (*exchange.Account, error) {
+	balances := make(map[exchange.Currency]exchange.AccountBalance)
+	for _, ccy := range []string{"BTC", "ETH", "USDC"} {
+		params := url.Values{"currency": {ccy}}
+		data, err := d.call("private/get_account_summary", params, true)
+		if err != nil {
+			continue // currency not enabled on this account
+		}
+		var result struct {
+			AvailableFunds float64 `json:"available_funds"`
+			Equity         float64 `json:"equity"`
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			continue
+		}
+		balances[exchange.Currency(ccy)] = exchange.AccountBalance{
+			Available: result.AvailableFunds,
+			Frozen:    result.Equity - result.AvailableFunds,
+		}
+	}
+	return &exchange.Account{Balances: balances}, nil
+}
+
+type deribitOrder struct {
+	OrderID        string  `json:"order_id"`
+	Direction      string  `json:"direction"`
+	Price          float64 `json:"price"`
+	Amount         float64 `json:"amount"`
+	FilledAmount   float64 `json:"filled_amount"`
+	OrderState     string  `json:"order_state"`
+	LastUpdateTime int64   `json:"last_update_timestamp"`
+}
+
+func (r deribitOrder) toOrder(pair exchange.CurrencyPair) *exchange.Order {
+	return &exchange.Order{
+		OrderID:    r.OrderID,
+		Pair:       pair,
+		Side:       exchange.TradeSide(r.Direction),
+		Price:      r.Price,
+		Quantity:   r.Amount,
+		DealAmount: r.FilledAmount,
+		Status:     toOrderStatus(r.OrderState),
+		Timestamp:  r.LastUpdateTime,
+	}
+}
+
+func toOrderStatus(deribitState string) exchange.OrderStatus {
+	switch deribitState {
+	case "filled":
+		return exchange.OrderStatusFilled
+	case "cancelled":
+		return exchange.OrderStatusCancelled
+	case "rejected":
+		return exchange.OrderStatusRejected
+	default:
+		return exchange.OrderStatusWorking
+	}
+}
+
+func toDeribitResolution(period exchange.KlinePeriod) string {
+	switch period {
+	case exchange.KlinePeriod1Min:
+		return "1"
+	case exchange.KlinePeriod5Min:
+		return "5"
+	case exchange.KlinePeriod15Min:
+		return "15"
+	case exchange.KlinePeriod1Hour:
+		return "60"
+	case exchange.KlinePeriod1Day:
+		return "1D"
+	default:
+		return "1"
+	}
+}
+
+func periodDuration(period exchange.KlinePeriod) time.Duration {
+	switch period {
+	case exchange.KlinePeriod1Min:
+		return time.Minute
+	case exchange.KlinePeriod5Min:
+		return 5 * time.Minute
+	case exchange.KlinePeriod15Min:
+		return 15 * time.Minute
+	case exchange.KlinePeriod1Hour:
+		return time.Hour
+	case exchange.KlinePeriod1Day:
+		return 24 * time.Hour
+	default:
+		return time.Minute
+	}
+}
+
+func toDepthRecords(rows [][]float64) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{Price: row[0], Amount: row[1]})
+	}
+	return records
+}
@@ -0,0 +1,200 @@
+// Package exchange defines a venue-agnostic trading interface. Each
+// supported exchange (OKX, Bybit, Deribit, Binance, ...) implements
+// Exchange in its own subpackage, translating the shared types below
+// into that venue's REST calls and symbol conventions.
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Currency is a single asset code, e.g. "BTC" or "USDT".
+type Currency string
+
+// CurrencyPair is a base/quote pair, e.g. BTC-USDT.
+type CurrencyPair struct {
+	Basis   Currency
+	Counter Currency
+}
+
+// ToSymbol renders the pair using the given join string, e.g.
+// ToSymbol("-") -> "BTC-USDT", ToSymbol("") -> "BTCUSDT".
+func (p CurrencyPair) ToSymbol(join string) string {
+	return fmt.Sprintf("%s%s%s", p.Basis, join, p.Counter)
+}
+
+func (p CurrencyPair) String() string {
+	return p.ToSymbol("_")
+}
+
+// Ticker is a venue's best bid/ask/last snapshot for a pair.
+type Ticker struct {
+	Pair      CurrencyPair
+	Last      float64
+	Buy       float64
+	Sell      float64
+	High      float64
+	Low       float64
+	Volume24h float64
+	Timestamp int64
+}
+
+// DepthRecord is a single price/amount level in an order book.
+type DepthRecord struct {
+	Price  float64
+	Amount float64
+}
+
+// Depth is an order book snapshot, best price first in each side.
+type Depth struct {
+	Pair      CurrencyPair
+	Bids      []DepthRecord
+	Asks      []DepthRecord
+	Timestamp int64
+}
+
+// KlinePeriod identifies a candle interval understood by GetKlineRecords.
+type KlinePeriod string
+
+const (
+	KlinePeriod1Min  KlinePeriod = "1m"
+	KlinePeriod5Min  KlinePeriod = "5m"
+	KlinePeriod15Min KlinePeriod = "15m"
+	KlinePeriod1Hour KlinePeriod = "1h"
+	KlinePeriod1Day  KlinePeriod = "1d"
+)
+
+// Kline is a single OHLCV candle.
+type Kline struct {
+	Pair      CurrencyPair
+	Timestamp int64
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// TradeSide distinguishes buy/sell for order placement.
+type TradeSide string
+
+const (
+	Buy  TradeSide = "buy"
+	Sell TradeSide = "sell"
+)
+
+// OrderStatus is the venue-reported lifecycle state of an order.
+type OrderStatus string
+
+const (
+	OrderStatusWorking   OrderStatus = "working"
+	OrderStatusFilled    OrderStatus = "filled"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRejected  OrderStatus = "rejected"
+)
+
+// Order is a single limit order, as placed or as reported back by a venue.
+type Order struct {
+	OrderID    string
+	Pair       CurrencyPair
+	Side       TradeSide
+	Price      float64
+	Quantity   float64
+	DealAmount float64
+	Status     OrderStatus
+	Timestamp  int64
+}
+
+// Account is a venue's reported balances, keyed by currency code.
+type Account struct {
+	Balances map[Currency]AccountBalance
+}
+
+// AccountBalance is the available/frozen split for one currency.
+type AccountBalance struct {
+	Available float64
+	Frozen    float64
+}
+
+// OptionalParameter mutates a request's query/body values. It lets callers
+// append pagination, time ranges, or state filters to a call without the
+// Exchange interface itself growing new parameters for every new filter.
+type OptionalParameter func(values url.Values)
+
+// WithFromTo scopes a call to a time range, in the format each venue's
+// implementation expects (usually unix millis).
+func WithFromTo(from, to string) OptionalParameter {
+	return func(values url.Values) {
+		if from != "" {
+			values.Set("from", from)
+		}
+		if to != "" {
+			values.Set("to", to)
+		}
+	}
+}
+
+// WithLimit caps the number of records a call returns.
+func WithLimit(limit int) OptionalParameter {
+	return func(values url.Values) {
+		values.Set("limit", fmt.Sprintf("%d", limit))
+	}
+}
+
+// WithState filters orders/history by lifecycle state.
+func WithState(state OrderStatus) OptionalParameter {
+	return func(values url.Values) {
+		values.Set("state", string(state))
+	}
+}
+
+func ApplyOptionalParameters(values url.Values, opts ...OptionalParameter) url.Values {
+	for _, opt := range opts {
+		opt(values)
+	}
+	return values
+}
+
+// StatusError wraps a venue's non-2xx HTTP response with its status
+// code, so callers can distinguish transient overload (5xx, 429) from an
+// ordinary request rejection without string-matching the error text.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *StatusError) Error() string { return e.Err.Error() }
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// IsTransient reports whether err is a StatusError for a 429 (rate
+// limited) or 5xx (server error) response -- the kind of failure a
+// circuit breaker should count toward tripping a halt, as opposed to an
+// ordinary rejection.
+func IsTransient(err error) bool {
+	var se *StatusError
+	if !errors.As(err, &se) {
+		return false
+	}
+	return se.StatusCode == http.StatusTooManyRequests || se.StatusCode >= http.StatusInternalServerError
+}
+
+// Exchange is the venue-agnostic surface every supported exchange
+// subpackage implements. Callers dispatch through a registry keyed by
+// exchange name rather than importing a specific venue package directly.
+type Exchange interface {
+	GetTicker(pair CurrencyPair) (*Ticker, error)
+	GetDepth(size int, pair CurrencyPair) (*Depth, error)
+	GetKlineRecords(pair CurrencyPair, period KlinePeriod, size int, opts ...OptionalParameter) ([]Kline, error)
+
+	LimitBuy(pair CurrencyPair, price, quantity float64, opts ...OptionalParameter) (*Order, error)
+	LimitSell(pair CurrencyPair, price, quantity float64, opts ...OptionalParameter) (*Order, error)
+	CancelOrder(orderID string, pair CurrencyPair) error
+	GetOneOrder(orderID string, pair CurrencyPair) (*Order, error)
+	GetUnfinishOrders(pair CurrencyPair) ([]Order, error)
+	GetOrderHistorys(pair CurrencyPair, opts ...OptionalParameter) ([]Order, error)
+
+	GetAccount() (*Account, error)
+}
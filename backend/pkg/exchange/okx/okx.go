@@ -0,0 +1,405 @@
+// Package okx implements the exchange.Exchange interface against OKX's
+// v5 REST API. Symbols are normalized to OKX's "BASE-COUNTER" form, e.g.
+// CurrencyPair{BTC, USDT} -> "BTC-USDT".
+package okx
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+const baseURL = "https://www.okx.com"
+
+// Credentials are OKX's three-part API key: key, secret, and a passphrase
+// chosen when the key was created.
+type Credentials struct {
+	APIKey     string
+	SecretKey  string
+	Passphrase string
+}
+
+// OKX is a REST client for a single OKX account (or an unauthenticated
+// client for public-only endpoints when Credentials is the zero value).
+type OKX struct {
+	creds      Credentials
+	httpClient *http.Client
+}
+
+// New returns an OKX client. Pass a zero-value Credentials for
+// public-endpoint-only use (GetTicker, GetDepth, GetKlineRecords).
+func New(creds Credentials) *OKX {
+	return &OKX{
+		creds:      creds,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func toOKXSymbol(pair exchange.CurrencyPair) string {
+	return pair.ToSymbol("-")
+}
+
+// sign implements OKX's HMAC-SHA256 request signing: base64(hmac(secret,
+// timestamp+method+requestPath+body)).
+func (o *OKX) sign(method, requestPath, body, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(o.creds.SecretKey))
+	mac.Write([]byte(timestamp + method + requestPath + body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (o *OKX) do(method, requestPath string, body []byte, authed bool) ([]byte, error) {
+	req, err := http.NewRequest(method, baseURL+requestPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if authed {
+		timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+		req.Header.Set("OK-ACCESS-KEY", o.creds.APIKey)
+		req.Header.Set("OK-ACCESS-SIGN", o.sign(method, requestPath, string(body), timestamp))
+		req.Header.Set("OK-ACCESS-TIMESTAMP", timestamp)
+		req.Header.Set("OK-ACCESS-PASSPHRASE", o.creds.Passphrase)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("okx: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &exchange.StatusError{StatusCode: resp.StatusCode, Err: fmt.Errorf("okx: http %d", resp.StatusCode)}
+	}
+
+	var envelope struct {
+		Code string          `json:"code"`
+		Msg  string          `json:"msg"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("okx: decode response: %w", err)
+	}
+	if envelope.Code != "0" {
+		return nil, fmt.Errorf("okx: %s (code %s)", envelope.Msg, envelope.Code)
+	}
+	return envelope.Data, nil
+}
+
+func (o *OKX) GetTicker(pair exchange.CurrencyPair) (*exchange.Ticker, error) {
+	data, err := o.do(http.MethodGet, "/api/v5/market/ticker?instId="+toOKXSymbol(pair), nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Last   string `json:"last"`
+		BidPx  string `json:"bidPx"`
+		AskPx  string `json:"askPx"`
+		High24 string `json:"high24h"`
+		Low24  string `json:"low24h"`
+		Vol24  string `json:"vol24h"`
+		TS     string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode ticker: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("okx: no ticker for %s", toOKXSymbol(pair))
+	}
+	r := rows[0]
+	ts, _ := strconv.ParseInt(r.TS, 10, 64)
+	return &exchange.Ticker{
+		Pair:      pair,
+		Last:      parseFloat(r.Last),
+		Buy:       parseFloat(r.BidPx),
+		Sell:      parseFloat(r.AskPx),
+		High:      parseFloat(r.High24),
+		Low:       parseFloat(r.Low24),
+		Volume24h: parseFloat(r.Vol24),
+		Timestamp: ts,
+	}, nil
+}
+
+func (o *OKX) GetDepth(size int, pair exchange.CurrencyPair) (*exchange.Depth, error) {
+	path := fmt.Sprintf("/api/v5/market/books?instId=%s&sz=%d", toOKXSymbol(pair), size)
+	data, err := o.do(http.MethodGet, path, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Bids [][]string `json:"bids"`
+		Asks [][]string `json:"asks"`
+		TS   string     `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode depth: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("okx: no depth for %s", toOKXSymbol(pair))
+	}
+	ts, _ := strconv.ParseInt(rows[0].TS, 10, 64)
+	return &exchange.Depth{
+		Pair:      pair,
+		Bids:      toDepthRecords(rows[0].Bids),
+		Asks:      toDepthRecords(rows[0].Asks),
+		Timestamp: ts,
+	}, nil
+}
+
+func (o *OKX) GetKlineRecords(pair exchange.CurrencyPair, period exchange.KlinePeriod, size int, opts ...exchange.OptionalParameter) ([]exchange.Kline, error) {
+	values := url.Values{}
+	values.Set("instId", toOKXSymbol(pair))
+	values.Set("bar", toOKXBar(period))
+	values.Set("limit", strconv.Itoa(size))
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := o.do(http.MethodGet, "/api/v5/market/candles?"+values.Encode(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode klines: %w", err)
+	}
+
+	klines := make([]exchange.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(row[0], 10, 64)
+		klines = append(klines, exchange.Kline{
+			Pair:      pair,
+			Timestamp: ts,
+			Open:      parseFloat(row[1]),
+			High:      parseFloat(row[2]),
+			Low:       parseFloat(row[3]),
+			Close:     parseFloat(row[4]),
+			Volume:    parseFloat(row[5]),
+		})
+	}
+	return klines, nil
+}
+
+func (o *OKX) placeOrder(pair exchange.CurrencyPair, side exchange.TradeSide, price, quantity float64) (*exchange.Order, error) {
+	body, err := json.Marshal(map[string]string{
+		"instId":  toOKXSymbol(pair),
+		"tdMode":  "cash",
+		"side":    string(side),
+		"ordType": "limit",
+		"px":      strconv.FormatFloat(price, 'f', -1, 64),
+		"sz":      strconv.FormatFloat(quantity, 'f', -1, 64),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := o.do(http.MethodPost, "/api/v5/trade/order", body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		OrdID string `json:"ordId"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode order response: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("okx: order was not acknowledged")
+	}
+
+	return &exchange.Order{
+		OrderID:  rows[0].OrdID,
+		Pair:     pair,
+		Side:     side,
+		Price:    price,
+		Quantity: quantity,
+		Status:   exchange.OrderStatusWorking,
+	}, nil
+}
+
+func (o *OKX) LimitBuy(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return o.placeOrder(pair, exchange.Buy, price, quantity)
+}
+
+func (o *OKX) LimitSell(pair exchange.CurrencyPair, price, quantity float64, opts ...exchange.OptionalParameter) (*exchange.Order, error) {
+	return o.placeOrder(pair, exchange.Sell, price, quantity)
+}
+
+func (o *OKX) CancelOrder(orderID string, pair exchange.CurrencyPair) error {
+	body, err := json.Marshal(map[string]string{
+		"instId": toOKXSymbol(pair),
+		"ordId":  orderID,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = o.do(http.MethodPost, "/api/v5/trade/cancel-order", body, true)
+	return err
+}
+
+func (o *OKX) GetOneOrder(orderID string, pair exchange.CurrencyPair) (*exchange.Order, error) {
+	path := fmt.Sprintf("/api/v5/trade/order?instId=%s&ordId=%s", toOKXSymbol(pair), orderID)
+	data, err := o.do(http.MethodGet, path, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []okxOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode order: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("okx: order %s not found", orderID)
+	}
+	return rows[0].toOrder(pair), nil
+}
+
+func (o *OKX) GetUnfinishOrders(pair exchange.CurrencyPair) ([]exchange.Order, error) {
+	data, err := o.do(http.MethodGet, "/api/v5/trade/orders-pending?instId="+toOKXSymbol(pair), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []okxOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode orders: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (o *OKX) GetOrderHistorys(pair exchange.CurrencyPair, opts ...exchange.OptionalParameter) ([]exchange.Order, error) {
+	values := url.Values{}
+	values.Set("instId", toOKXSymbol(pair))
+	values.Set("instType", "SPOT")
+	exchange.ApplyOptionalParameters(values, opts...)
+
+	data, err := o.do(http.MethodGet, "/api/v5/trade/orders-history?"+values.Encode(), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var rows []okxOrder
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode order history: %w", err)
+	}
+	orders := make([]exchange.Order, 0, len(rows))
+	for _, r := range rows {
+		orders = append(orders, *r.toOrder(pair))
+	}
+	return orders, nil
+}
+
+func (o *OKX) GetAccount() (*exchange.Account, error) {
+	data, err := o.do(http.MethodGet, "/api/v5/account/balance", nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Details []struct {
+			Ccy       string `json:"ccy"`
+			AvailBal  string `json:"availBal"`
+			FrozenBal string `json:"frozenBal"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("okx: decode account: %w", err)
+	}
+
+	balances := make(map[exchange.Currency]exchange.AccountBalance)
+	if len(rows) > 0 {
+		for _, d := range rows[0].Details {
+			balances[exchange.Currency(d.Ccy)] = exchange.AccountBalance{
+				Available: parseFloat(d.AvailBal),
+				Frozen:    parseFloat(d.FrozenBal),
+			}
+		}
+	}
+	return &exchange.Account{Balances: balances}, nil
+}
+
+type okxOrder struct {
+	OrdID     string `json:"ordId"`
+	Side      string `json:"side"`
+	Px        string `json:"px"`
+	Sz        string `json:"sz"`
+	AccFillSz string `json:"accFillSz"`
+	State     string `json:"state"`
+	UTime     string `json:"uTime"`
+}
+
+func (r okxOrder) toOrder(pair exchange.CurrencyPair) *exchange.Order {
+	ts, _ := strconv.ParseInt(r.UTime, 10, 64)
+	return &exchange.Order{
+		OrderID:    r.OrdID,
+		Pair:       pair,
+		Side:       exchange.TradeSide(r.Side),
+		Price:      parseFloat(r.Px),
+		Quantity:   parseFloat(r.Sz),
+		DealAmount: parseFloat(r.AccFillSz),
+		Status:     toOrderStatus(r.State),
+		Timestamp:  ts,
+	}
+}
+
+func toOrderStatus(okxState string) exchange.OrderStatus {
+	switch okxState {
+	case "filled":
+		return exchange.OrderStatusFilled
+	case "canceled":
+		return exchange.OrderStatusCancelled
+	default:
+		return exchange.OrderStatusWorking
+	}
+}
+
+func toOKXBar(period exchange.KlinePeriod) string {
+	switch period {
+	case exchange.KlinePeriod1Min:
+		return "1m"
+	case exchange.KlinePeriod5Min:
+		return "5m"
+	case exchange.KlinePeriod15Min:
+		return "15m"
+	case exchange.KlinePeriod1Hour:
+		return "1H"
+	case exchange.KlinePeriod1Day:
+		return "1D"
+	default:
+		return "1m"
+	}
+}
+
+func toDepthRecords(rows [][]string) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{
+			Price:  parseFloat(row[0]),
+			Amount: parseFloat(row[1]),
+		})
+	}
+	return records
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
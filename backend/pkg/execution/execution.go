@@ -0,0 +1,347 @@
+// Package execution slices a large OTC order into smaller child orders
+// (TWAP or VWAP) when a straight market sweep would incur too much
+// slippage, requoting children against live depth as they work.
+package execution
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/halts"
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+)
+
+// Mode selects how a parent order's quantity is split across its children.
+type Mode string
+
+const (
+	// ModeTWAP splits the parent into equal-sized children spaced evenly
+	// across Config.Duration.
+	ModeTWAP Mode = "twap"
+	// ModeVWAP sizes children proportional to historical traded volume
+	// over Config.Duration, using Config.VolumeLookback klines.
+	ModeVWAP Mode = "vwap"
+)
+
+// Config describes one smart-execution run.
+type Config struct {
+	ExchangeName string // venue name, e.g. "OKX" -- used to consult the halt registry
+	Pair         exchange.CurrencyPair
+	Side         exchange.TradeSide
+	Quantity     float64
+	Mode         Mode
+	Duration     time.Duration // total time to work the order over
+	Slices       int           // number of child orders in ModeTWAP; ModeVWAP places one child per kline instead
+	TickSize     float64       // venue tick size, for drift comparisons
+	DriftTicks   float64       // k: cancel-and-replace a child once its price drifts k*TickSize from top-of-book
+
+	// VolumeLookback is how many trailing 1-minute klines ModeVWAP weighs
+	// children against, one child per kline returned. Ignored in ModeTWAP. Callers typically size this
+	// to Duration so the volume profile covers the whole run.
+	VolumeLookback int
+}
+
+// ChildState is one child order's last-known status.
+type ChildState struct {
+	Index    int
+	OrderID  string
+	Price    float64
+	Quantity float64
+	Status   exchange.OrderStatus
+}
+
+// EstimateSlippage walks the book on the side a market order of the given
+// side/quantity would sweep (asks for a buy, bids for a sell) and returns
+// the fractional difference between the volume-weighted average fill
+// price and the best available price. A thin book returns a large value.
+func EstimateSlippage(depth *exchange.Depth, side exchange.TradeSide, quantity float64) float64 {
+	levels := depth.Asks
+	if side == exchange.Sell {
+		levels = depth.Bids
+	}
+	if len(levels) == 0 || quantity <= 0 {
+		return 1 // no liquidity observed; treat as maximally slippy
+	}
+
+	best := levels[0].Price
+	remaining := quantity
+	var notional float64
+	var filled float64
+
+	for _, level := range levels {
+		take := level.Amount
+		if take > remaining {
+			take = remaining
+		}
+		notional += take * level.Price
+		filled += take
+		remaining -= take
+		if remaining <= 0 {
+			break
+		}
+	}
+	if filled == 0 {
+		return 1
+	}
+	if remaining > 0 {
+		// Book doesn't have enough depth to fill the order at all; treat
+		// the unfillable remainder as executing at the worst observed level.
+		worst := levels[len(levels)-1].Price
+		notional += remaining * worst
+		filled += remaining
+	}
+
+	avgPrice := notional / filled
+	if best == 0 {
+		return 1
+	}
+	return absFloat(avgPrice-best) / best
+}
+
+func absFloat(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// Executor works a parent order's children to completion, persisting
+// every transition against the parent through store.
+type Executor struct {
+	venue         exchange.Exchange
+	st            store.Store
+	callID        string
+	parentOrderID string
+	cfg           Config
+
+	registry *halts.Registry // nil disables the halt check between slices
+	breaker  *halts.Breaker  // nil disables feeding venue errors to the circuit breaker
+
+	children []ChildState
+}
+
+// New returns an Executor for cfg, to be started with Run. parentOrderID
+// identifies the parent in the store's order history; it doesn't
+// correspond to a real exchange order since the parent is never sent to
+// the venue directly. registry and breaker may be nil to run without halt
+// enforcement, e.g. in tests.
+func New(venue exchange.Exchange, st store.Store, callID, parentOrderID string, cfg Config, registry *halts.Registry, breaker *halts.Breaker) *Executor {
+	if cfg.Slices <= 0 {
+		cfg.Slices = 1
+	}
+	return &Executor{venue: venue, st: st, callID: callID, parentOrderID: parentOrderID, cfg: cfg, registry: registry, breaker: breaker}
+}
+
+// Children returns a snapshot of each child's last-known state.
+func (e *Executor) Children() []ChildState {
+	return append([]ChildState(nil), e.children...)
+}
+
+// Run works the parent to completion, blocking until every child has been
+// placed and its final state observed (or ctx is cancelled). Call it in
+// its own goroutine for a conversational flow that shouldn't block on it.
+func (e *Executor) Run(ctx context.Context) error {
+	weights, err := e.sliceWeights(ctx)
+	if err != nil {
+		return fmt.Errorf("execution: computing slice weights: %w", err)
+	}
+
+	interval := e.cfg.Duration / time.Duration(len(weights))
+
+	for i, weight := range weights {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if e.registry != nil {
+			if halt, blocked := e.registry.Check(e.cfg.ExchangeName, e.cfg.Pair.String()); blocked {
+				return fmt.Errorf("execution: halted: %s", halt.Reason)
+			}
+		}
+
+		qty := e.cfg.Quantity * weight
+		if err := e.runChild(ctx, i, qty); err != nil {
+			log.Printf("execution: child %d/%d for parent %s failed: %v", i+1, len(weights), e.parentOrderID, err)
+		}
+
+		if i < len(weights)-1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+	return nil
+}
+
+// sliceWeights returns, for each child, the fraction of Quantity it
+// should take. TWAP splits evenly; VWAP proportions to recent traded
+// volume so children track where the market is actually trading.
+func (e *Executor) sliceWeights(ctx context.Context) ([]float64, error) {
+	if e.cfg.Mode != ModeVWAP {
+		weights := make([]float64, e.cfg.Slices)
+		for i := range weights {
+			weights[i] = 1.0 / float64(e.cfg.Slices)
+		}
+		return weights, nil
+	}
+
+	lookback := e.cfg.VolumeLookback
+	if lookback <= 0 {
+		lookback = e.cfg.Slices
+	}
+	klines, err := e.venue.GetKlineRecords(e.cfg.Pair, exchange.KlinePeriod1Min, lookback, exchange.WithLimit(lookback))
+	if err != nil || len(klines) == 0 {
+		return nil, fmt.Errorf("fetching volume history: %w", err)
+	}
+
+	var total float64
+	for _, k := range klines {
+		total += k.Volume
+	}
+	if total == 0 {
+		// No observed volume; fall back to an even split.
+		weights := make([]float64, len(klines))
+		for i := range weights {
+			weights[i] = 1.0 / float64(len(klines))
+		}
+		return weights, nil
+	}
+
+	weights := make([]float64, len(klines))
+	for i, k := range klines {
+		weights[i] = k.Volume / total
+	}
+	return weights, nil
+}
+
+// runChild places one child order at the current top-of-book, then polls
+// until it fills or its price drifts more than DriftTicks*TickSize from
+// the book, in which case it's cancelled and replaced once at the new
+// top-of-book before the loop moves to the next slice.
+func (e *Executor) runChild(ctx context.Context, index int, quantity float64) error {
+	price, err := e.topOfBookPrice()
+	if err != nil {
+		return err
+	}
+
+	order, err := e.placeChild(price, quantity)
+	if err != nil {
+		e.observeVenueError(err)
+		return err
+	}
+	e.recordChild(index, order, exchange.OrderStatusWorking)
+
+	const pollInterval = 2 * time.Second
+	replaced := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+
+		current, err := e.venue.GetOneOrder(order.OrderID, e.cfg.Pair)
+		if err != nil {
+			return fmt.Errorf("polling child %s: %w", order.OrderID, err)
+		}
+		if current.Status == exchange.OrderStatusFilled || current.Status == exchange.OrderStatusCancelled || current.Status == exchange.OrderStatusRejected {
+			e.recordChild(index, current, current.Status)
+			return nil
+		}
+
+		if replaced {
+			continue // only requote once per child to bound churn
+		}
+
+		latest, err := e.topOfBookPrice()
+		if err != nil {
+			continue
+		}
+		if absFloat(latest-order.Price) <= e.cfg.DriftTicks*e.cfg.TickSize {
+			continue
+		}
+
+		if err := e.venue.CancelOrder(order.OrderID, e.cfg.Pair); err != nil {
+			log.Printf("execution: cancel-replace of child %s failed: %v", order.OrderID, err)
+			continue
+		}
+		e.recordChild(index, order, exchange.OrderStatusCancelled)
+
+		remaining := quantity - current.DealAmount
+		replacement, err := e.placeChild(latest, remaining)
+		if err != nil {
+			e.observeVenueError(err)
+			return fmt.Errorf("replacing child %s: %w", order.OrderID, err)
+		}
+		order = replacement
+		e.recordChild(index, order, exchange.OrderStatusWorking)
+		replaced = true
+	}
+}
+
+func (e *Executor) topOfBookPrice() (float64, error) {
+	depth, err := e.venue.GetDepth(5, e.cfg.Pair)
+	if err != nil {
+		return 0, fmt.Errorf("fetching depth: %w", err)
+	}
+	if e.cfg.Side == exchange.Sell {
+		if len(depth.Bids) == 0 {
+			return 0, fmt.Errorf("no bids available")
+		}
+		return depth.Bids[0].Price, nil
+	}
+	if len(depth.Asks) == 0 {
+		return 0, fmt.Errorf("no asks available")
+	}
+	return depth.Asks[0].Price, nil
+}
+
+func (e *Executor) placeChild(price, quantity float64) (*exchange.Order, error) {
+	if e.cfg.Side == exchange.Sell {
+		return e.venue.LimitSell(e.cfg.Pair, price, quantity)
+	}
+	return e.venue.LimitBuy(e.cfg.Pair, price, quantity)
+}
+
+// observeVenueError feeds a failed venue call to the circuit breaker, if
+// one is wired up, so repeated 5xx/429 responses while working this
+// parent can trip an automatic halt.
+func (e *Executor) observeVenueError(err error) {
+	if e.breaker != nil {
+		e.breaker.ObserveVenueError(e.cfg.ExchangeName, err)
+	}
+}
+
+func (e *Executor) recordChild(index int, order *exchange.Order, status exchange.OrderStatus) {
+	e.children = append(e.children, ChildState{
+		Index:    index,
+		OrderID:  order.OrderID,
+		Price:    order.Price,
+		Quantity: order.Quantity,
+		Status:   status,
+	})
+
+	err := e.st.AppendOrder(context.Background(), store.Order{
+		CallID:        e.callID,
+		Exchange:      "", // the store's session row already records the venue for this call
+		Symbol:        e.cfg.Pair.String(),
+		Side:          string(e.cfg.Side),
+		Price:         order.Price,
+		Quantity:      order.Quantity,
+		OrderID:       order.OrderID,
+		ParentOrderID: e.parentOrderID,
+		State:         string(status),
+		Timestamp:     time.Now(),
+	})
+	if err != nil {
+		log.Printf("execution: failed to persist child %s: %v", order.OrderID, err)
+	}
+}
@@ -0,0 +1,88 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+func TestEstimateSlippage(t *testing.T) {
+	depth := &exchange.Depth{
+		Asks: []exchange.DepthRecord{{Price: 100, Amount: 1}, {Price: 101, Amount: 1}},
+		Bids: []exchange.DepthRecord{{Price: 99, Amount: 1}, {Price: 98, Amount: 1}},
+	}
+
+	tests := []struct {
+		name     string
+		side     exchange.TradeSide
+		quantity float64
+		want     float64
+	}{
+		{"fills entirely at the best ask", exchange.Buy, 1, 0},
+		{"walks into the second ask level", exchange.Buy, 2, 0.5 / 100},
+		{"fills entirely at the best bid", exchange.Sell, 1, 0},
+		{"zero quantity is maximally slippy", exchange.Buy, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateSlippage(depth, tt.side, tt.quantity)
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("EstimateSlippage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeKlineVenue is a minimal exchange.Exchange stub that only implements
+// GetKlineRecords, returning up to size of its fixed klines -- enough to
+// observe which lookback window sliceWeights actually requests.
+type fakeKlineVenue struct {
+	exchange.Exchange
+	klines []exchange.Kline
+}
+
+func (f *fakeKlineVenue) GetKlineRecords(pair exchange.CurrencyPair, period exchange.KlinePeriod, size int, opts ...exchange.OptionalParameter) ([]exchange.Kline, error) {
+	if size > len(f.klines) {
+		size = len(f.klines)
+	}
+	return f.klines[:size], nil
+}
+
+func TestSliceWeightsVWAPUsesVolumeLookbackNotSlices(t *testing.T) {
+	venue := &fakeKlineVenue{klines: []exchange.Kline{{Volume: 1}, {Volume: 2}, {Volume: 3}}}
+	e := &Executor{venue: venue, cfg: Config{Mode: ModeVWAP, Slices: 1, VolumeLookback: 3}}
+
+	weights, err := e.sliceWeights(context.Background())
+	if err != nil {
+		t.Fatalf("sliceWeights() error = %v", err)
+	}
+	if len(weights) != 3 {
+		t.Fatalf("len(weights) = %d, want 3 (VolumeLookback, not Slices=1)", len(weights))
+	}
+
+	want := []float64{1.0 / 6, 2.0 / 6, 3.0 / 6}
+	for i, w := range weights {
+		if diff := w - want[i]; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("weights[%d] = %v, want %v", i, w, want[i])
+		}
+	}
+}
+
+func TestSliceWeightsTWAPSplitsEvenly(t *testing.T) {
+	e := &Executor{cfg: Config{Mode: ModeTWAP, Slices: 4}}
+
+	weights, err := e.sliceWeights(context.Background())
+	if err != nil {
+		t.Fatalf("sliceWeights() error = %v", err)
+	}
+	if len(weights) != 4 {
+		t.Fatalf("len(weights) = %d, want 4", len(weights))
+	}
+	for i, w := range weights {
+		if diff := w - 0.25; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("weights[%d] = %v, want 0.25", i, w)
+		}
+	}
+}
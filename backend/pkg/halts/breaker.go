@@ -0,0 +1,153 @@
+package halts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// BreakerConfig tunes when Breaker trips an automatic halt.
+type BreakerConfig struct {
+	// PriceMovePct is the fractional price move (e.g. 0.05 for 5%) within
+	// PriceMoveWindow that halts the symbol.
+	PriceMovePct    float64
+	PriceMoveWindow time.Duration
+
+	// VenueErrorThreshold is how many transient (5xx/429) venue errors
+	// within VenueErrorWindow halt that exchange.
+	VenueErrorThreshold int
+	VenueErrorWindow    time.Duration
+
+	// HaltDuration is how long an automatic halt lasts before it clears
+	// itself, unless Registry.Clear clears it sooner. The admin API only
+	// places and lists halts; it has no endpoint for clearing one.
+	HaltDuration time.Duration
+}
+
+// DefaultBreakerConfig halts a symbol on a 5% move in 30 seconds, or an
+// exchange on 5 transient errors in a minute, for 5 minutes at a time.
+var DefaultBreakerConfig = BreakerConfig{
+	PriceMovePct:        0.05,
+	PriceMoveWindow:     30 * time.Second,
+	VenueErrorThreshold: 5,
+	VenueErrorWindow:    time.Minute,
+	HaltDuration:        5 * time.Minute,
+}
+
+type priceSample struct {
+	price float64
+	at    time.Time
+}
+
+// Breaker watches streamed ticker prices and venue call outcomes and
+// automatically places halts through a Registry when either trips,
+// mirroring the halt an operator could place manually via the admin API.
+type Breaker struct {
+	registry *Registry
+	cfg      BreakerConfig
+
+	mu        sync.Mutex
+	prices    map[string][]priceSample // "exchange:symbol" -> recent samples
+	venueErrs map[string][]time.Time   // exchange -> recent transient-error timestamps
+}
+
+// NewBreaker returns a Breaker that places halts through registry.
+func NewBreaker(registry *Registry, cfg BreakerConfig) *Breaker {
+	return &Breaker{
+		registry:  registry,
+		cfg:       cfg,
+		prices:    make(map[string][]priceSample),
+		venueErrs: make(map[string][]time.Time),
+	}
+}
+
+func priceKey(exchangeName, symbol string) string {
+	return exchangeName + ":" + symbol
+}
+
+// ObservePrice records a streamed price for exchangeName/symbol and trips
+// an automatic symbol-scoped halt if it has moved by at least
+// cfg.PriceMovePct within cfg.PriceMoveWindow.
+func (b *Breaker) ObservePrice(exchangeName, symbol string, price float64, now time.Time) {
+	if price <= 0 {
+		return
+	}
+
+	key := priceKey(exchangeName, symbol)
+	cutoff := now.Add(-b.cfg.PriceMoveWindow)
+
+	b.mu.Lock()
+	kept := b.prices[key][:0]
+	for _, s := range b.prices[key] {
+		if s.at.After(cutoff) {
+			kept = append(kept, s)
+		}
+	}
+	samples := append(kept, priceSample{price: price, at: now})
+	b.prices[key] = samples
+	oldest := samples[0].price
+	b.mu.Unlock()
+
+	if oldest == 0 {
+		return
+	}
+	move := (price - oldest) / oldest
+	if move < 0 {
+		move = -move
+	}
+	if move < b.cfg.PriceMovePct {
+		return
+	}
+
+	reason := fmt.Sprintf("circuit breaker: %s %s moved %.1f%% within %s", exchangeName, symbol, move*100, b.cfg.PriceMoveWindow)
+	b.trip(ScopeSymbol, exchangeName, symbol, reason)
+
+	b.mu.Lock()
+	delete(b.prices, key) // don't re-trip on every tick until the window refills
+	b.mu.Unlock()
+}
+
+// ObserveVenueError records the outcome of a venue call and trips an
+// automatic exchange-scoped halt once transient (5xx/429) errors reach
+// cfg.VenueErrorThreshold within cfg.VenueErrorWindow. Non-transient
+// errors (rejections, bad requests) are ignored.
+func (b *Breaker) ObserveVenueError(exchangeName string, err error) {
+	if err == nil || !exchange.IsTransient(err) {
+		return
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-b.cfg.VenueErrorWindow)
+
+	b.mu.Lock()
+	kept := b.venueErrs[exchangeName][:0]
+	for _, t := range b.venueErrs[exchangeName] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	errs := append(kept, now)
+	b.venueErrs[exchangeName] = errs
+	tripped := len(errs) >= b.cfg.VenueErrorThreshold
+	if tripped {
+		delete(b.venueErrs, exchangeName)
+	}
+	b.mu.Unlock()
+
+	if !tripped {
+		return
+	}
+	reason := fmt.Sprintf("circuit breaker: %d transient errors from %s within %s", b.cfg.VenueErrorThreshold, exchangeName, b.cfg.VenueErrorWindow)
+	b.trip(ScopeExchange, exchangeName, "", reason)
+}
+
+func (b *Breaker) trip(scope Scope, exchangeName, symbol, reason string) {
+	until := time.Now().Add(b.cfg.HaltDuration)
+	if _, err := b.registry.Place(context.Background(), scope, exchangeName, symbol, until, reason); err != nil {
+		log.Printf("halts: circuit breaker failed to place halt: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package halts implements a trading-halt registry: global, per-exchange,
+// or per-symbol blocks on new order placement, in effect until a
+// wall-clock time or until manually cleared. Halts are placed either by
+// an operator (the admin API in handlers) or automatically by Breaker,
+// and persist through pkg/store so they survive a restart and stay
+// visible across every backend instance sharing that store.
+package halts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+)
+
+// Scope is how broadly a halt applies.
+type Scope string
+
+const (
+	ScopeGlobal   Scope = "global"
+	ScopeExchange Scope = "exchange"
+	ScopeSymbol   Scope = "symbol"
+)
+
+// ErrInvalidScope is returned by Place for an unrecognized scope, or one
+// missing the exchange/symbol it requires.
+var ErrInvalidScope = fmt.Errorf("halts: scope must be %q, %q, or %q, with exchange/symbol set accordingly", ScopeGlobal, ScopeExchange, ScopeSymbol)
+
+// Registry checks order placement against the active set of halts and
+// creates new ones. It keeps an in-memory copy of the active set so Check
+// is cheap to call from the order-placement path, refreshing that copy on
+// every mutation and on Start's interval so a halt placed on one backend
+// instance is picked up by the others.
+type Registry struct {
+	st store.Store
+
+	mu     sync.RWMutex
+	active []store.Halt
+}
+
+// NewRegistry returns a Registry backed by st. Call Refresh (or Start)
+// before relying on Check, so halts placed before this process started
+// are picked up.
+func NewRegistry(st store.Store) *Registry {
+	return &Registry{st: st}
+}
+
+// Start refreshes the active set from store on interval until stop is
+// called, the same polling pattern store.StartReaper uses for session
+// expiry.
+func (r *Registry) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.Refresh(context.Background()); err != nil {
+					log.Printf("halts: refresh failed: %v", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// Refresh reloads the active halt set from store.
+func (r *Registry) Refresh(ctx context.Context) error {
+	active, err := r.st.ListActiveHalts(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("halts: refresh: %w", err)
+	}
+	r.mu.Lock()
+	r.active = active
+	r.mu.Unlock()
+	return nil
+}
+
+// Place creates a new halt, persists it through store, and refreshes the
+// in-memory active set before returning.
+func (r *Registry) Place(ctx context.Context, scope Scope, exchangeName, symbol string, until time.Time, reason string) (store.Halt, error) {
+	switch scope {
+	case ScopeGlobal:
+		exchangeName, symbol = "", ""
+	case ScopeExchange:
+		if exchangeName == "" {
+			return store.Halt{}, ErrInvalidScope
+		}
+		symbol = ""
+	case ScopeSymbol:
+		if exchangeName == "" || symbol == "" {
+			return store.Halt{}, ErrInvalidScope
+		}
+	default:
+		return store.Halt{}, ErrInvalidScope
+	}
+
+	h := store.Halt{
+		Scope:     string(scope),
+		Exchange:  exchangeName,
+		Symbol:    symbol,
+		Reason:    reason,
+		Until:     until,
+		CreatedAt: time.Now(),
+	}
+	id, err := r.st.SaveHalt(ctx, h)
+	if err != nil {
+		return store.Halt{}, fmt.Errorf("halts: place: %w", err)
+	}
+	h.ID = id
+
+	if err := r.Refresh(ctx); err != nil {
+		log.Printf("halts: refresh after place failed: %v", err)
+	}
+	return h, nil
+}
+
+// Clear marks a halt cleared and refreshes the active set.
+func (r *Registry) Clear(ctx context.Context, id int64) error {
+	if err := r.st.ClearHalt(ctx, id); err != nil {
+		return fmt.Errorf("halts: clear: %w", err)
+	}
+	if err := r.Refresh(ctx); err != nil {
+		log.Printf("halts: refresh after clear failed: %v", err)
+	}
+	return nil
+}
+
+// Active returns the cached active halt set, as of the last Refresh.
+func (r *Registry) Active() []store.Halt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]store.Halt(nil), r.active...)
+}
+
+// Check reports whether order placement on exchangeName/symbol is
+// currently blocked, and the active halt responsible if so.
+func (r *Registry) Check(exchangeName, symbol string) (store.Halt, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, h := range r.active {
+		switch Scope(h.Scope) {
+		case ScopeGlobal:
+			return h, true
+		case ScopeExchange:
+			if h.Exchange == exchangeName {
+				return h, true
+			}
+		case ScopeSymbol:
+			if h.Exchange == exchangeName && h.Symbol == symbol {
+				return h, true
+			}
+		}
+	}
+	return store.Halt{}, false
+}
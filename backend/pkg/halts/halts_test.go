@@ -0,0 +1,69 @@
+package halts
+
+import (
+	"testing"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+)
+
+func TestRegistryCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		active       []store.Halt
+		exchangeName string
+		symbol       string
+		wantBlocked  bool
+	}{
+		{
+			name:         "no halts",
+			exchangeName: "OKX",
+			symbol:       "BTC_USDT",
+			wantBlocked:  false,
+		},
+		{
+			name:         "global halt blocks every exchange and symbol",
+			active:       []store.Halt{{Scope: string(ScopeGlobal)}},
+			exchangeName: "Bybit",
+			symbol:       "ETH_USDT",
+			wantBlocked:  true,
+		},
+		{
+			name:         "exchange halt blocks that exchange regardless of symbol",
+			active:       []store.Halt{{Scope: string(ScopeExchange), Exchange: "OKX"}},
+			exchangeName: "OKX",
+			symbol:       "ETH_USDT",
+			wantBlocked:  true,
+		},
+		{
+			name:         "exchange halt doesn't block a different exchange",
+			active:       []store.Halt{{Scope: string(ScopeExchange), Exchange: "OKX"}},
+			exchangeName: "Bybit",
+			symbol:       "ETH_USDT",
+			wantBlocked:  false,
+		},
+		{
+			name:         "symbol halt requires both exchange and symbol to match",
+			active:       []store.Halt{{Scope: string(ScopeSymbol), Exchange: "OKX", Symbol: "BTC_USDT"}},
+			exchangeName: "OKX",
+			symbol:       "BTC_USDT",
+			wantBlocked:  true,
+		},
+		{
+			name:         "symbol halt doesn't match a differently-formatted symbol string",
+			active:       []store.Halt{{Scope: string(ScopeSymbol), Exchange: "OKX", Symbol: "BTC_USDT"}},
+			exchangeName: "OKX",
+			symbol:       "BTC-USDT",
+			wantBlocked:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Registry{active: tt.active}
+			_, blocked := r.Check(tt.exchangeName, tt.symbol)
+			if blocked != tt.wantBlocked {
+				t.Errorf("Check(%q, %q) blocked = %v, want %v", tt.exchangeName, tt.symbol, blocked, tt.wantBlocked)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Package nlu turns the free-form quantities and prices a trader might
+// say or type — "1.5 bitcoin at 65k", "half a BTC for sixty-five
+// thousand dollars" — into numbers the trading FSM can use. A bare
+// strconv.ParseFloat over the words misses word numbers, k/m/bn
+// suffixes, and fractions, so this package tokenizes the utterance and
+// walks a small grammar instead.
+package nlu
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Result is one number Extract pulled out of an utterance.
+type Result struct {
+	Value      float64
+	Unit       string  // attached asset/currency code, e.g. "BTC", "USD"; empty if none was recognized
+	Confidence float64 // 0..1: how sure Extract is this number was parsed correctly and assigned to the right field
+}
+
+// role classifies which field of an order a number most likely fills.
+type role int
+
+const (
+	roleUnknown role = iota
+	roleQuantity
+	rolePrice
+)
+
+// priceKeywords introduce a price when they immediately precede a
+// number, e.g. "at 65k", "for 65,000", "@ 65000".
+var priceKeywords = map[string]bool{"at": true, "for": true, "@": true, "price": true}
+
+// quantityKeywords introduce a quantity the same way.
+var quantityKeywords = map[string]bool{"quantity": true, "amount": true, "size": true}
+
+// assetUnits attach to a quantity when they immediately follow a number,
+// e.g. "1.5 bitcoin".
+var assetUnits = map[string]string{
+	"btc": "BTC", "bitcoin": "BTC",
+	"eth": "ETH", "ethereum": "ETH",
+	"sol": "SOL", "solana": "SOL",
+}
+
+// currencyUnits attach to a price the same way.
+var currencyUnits = map[string]string{
+	"usd": "USD", "usdt": "USDT", "usdc": "USDC", "dollars": "USD", "dollar": "USD",
+}
+
+// suffixScale covers trailing shorthand on a digit token, e.g. "65k".
+var suffixScale = map[string]float64{"k": 1e3, "m": 1e6, "bn": 1e9}
+
+// scaleWords covers the English scale words used after a run of
+// ones/tens/hundreds, e.g. "sixty five thousand".
+var scaleWords = map[string]float64{"thousand": 1e3, "million": 1e6, "billion": 1e9}
+
+// fractionWords are spoken fractions, e.g. "half a bitcoin".
+var fractionWords = map[string]float64{"half": 0.5, "quarter": 0.25}
+
+var onesAndTensWords = map[string]float64{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16, "seventeen": 17,
+	"eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30, "forty": 40,
+	"fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// tokenize lowercases text and splits it into words, separating out
+// leading currency symbols and hyphenated word-numbers ("sixty-five")
+// so the grammar below can walk them one word at a time.
+func tokenize(text string) []string {
+	text = strings.ToLower(text)
+	text = strings.ReplaceAll(text, "$", " $ ")
+	text = strings.ReplaceAll(text, "@", " @ ")
+	text = strings.ReplaceAll(text, ",", "")
+	text = strings.ReplaceAll(text, "-", " ")
+
+	fields := strings.Fields(text)
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if t := strings.Trim(f, ".!?"); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// parseFraction matches a spoken fraction, optionally followed by
+// "a"/"an" ("half a bitcoin" -> 0.5, consuming "half a").
+func parseFraction(tokens []string, i int) (value float64, consumed int, ok bool) {
+	v, found := fractionWords[tokens[i]]
+	if !found {
+		return 0, 0, false
+	}
+	consumed = 1
+	if i+1 < len(tokens) && (tokens[i+1] == "a" || tokens[i+1] == "an") {
+		consumed++
+	}
+	return v, consumed, true
+}
+
+// parseSuffixedDigits matches a digit token with a trailing shorthand
+// scale suffix, e.g. "65k" -> 65000.
+func parseSuffixedDigits(tok string) (float64, bool) {
+	for suffix, scale := range suffixScale {
+		if strings.HasSuffix(tok, suffix) && len(tok) > len(suffix) {
+			if v, err := strconv.ParseFloat(strings.TrimSuffix(tok, suffix), 64); err == nil {
+				return v * scale, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// isNumberWord reports whether tok is a word this grammar recognizes as
+// part of a number run (a one/ten, "hundred", or a scale word).
+func isNumberWord(tok string) bool {
+	if tok == "zero" || tok == "hundred" {
+		return true
+	}
+	_, isOnesOrTens := onesAndTensWords[tok]
+	_, isScale := scaleWords[tok]
+	return isOnesOrTens || isScale
+}
+
+// parseWordNumber walks a run of ones/tens/hundred/scale words, e.g.
+// "sixty five thousand" -> 65000, consuming 3 tokens.
+func parseWordNumber(tokens []string, start int) (value float64, consumed int, ok bool) {
+	if !isNumberWord(tokens[start]) {
+		return 0, 0, false
+	}
+
+	var total, current float64
+	i := start
+	for i < len(tokens) && isNumberWord(tokens[i]) {
+		tok := tokens[i]
+		switch {
+		case tok == "hundred":
+			if current == 0 {
+				current = 1
+			}
+			current *= 100
+		case scaleWords[tok] != 0:
+			if current == 0 {
+				current = 1
+			}
+			total += current * scaleWords[tok]
+			current = 0
+		default:
+			current += onesAndTensWords[tok]
+		}
+		i++
+	}
+
+	return total + current, i - start, true
+}
+
+// parseNumber tries, in order, a fraction, a leading currency symbol, a
+// suffixed digit, a plain digit, and a word-number run.
+func parseNumber(tokens []string, i int) (value float64, consumed int, confidence float64, ok bool) {
+	tok := tokens[i]
+
+	if v, c, matched := parseFraction(tokens, i); matched {
+		return v, c, 0.85, true
+	}
+
+	if tok == "$" {
+		if i+1 < len(tokens) {
+			if v, c, conf, matched := parseNumber(tokens, i+1); matched {
+				return v, c + 1, conf, true
+			}
+		}
+		return 0, 0, 0, false
+	}
+
+	if v, matched := parseSuffixedDigits(tok); matched {
+		return v, 1, 0.9, true
+	}
+
+	if v, err := strconv.ParseFloat(tok, 64); err == nil {
+		// A bare digit run can still be followed by a scale word ("65
+		// thousand dollars"), which must fold into this same number
+		// rather than being picked up as an unrelated second match.
+		if i+1 < len(tokens) {
+			if scale, isScale := scaleWords[tokens[i+1]]; isScale {
+				return v * scale, 2, 0.95, true
+			}
+		}
+		return v, 1, 0.95, true
+	}
+
+	if v, c, matched := parseWordNumber(tokens, i); matched {
+		return v, c, 0.85, true
+	}
+
+	return 0, 0, 0, false
+}
+
+// match is one number found in an utterance, with its role and unit
+// resolved from context.
+type match struct {
+	value      float64
+	unit       string
+	confidence float64
+	role       role
+}
+
+// scanNumbers walks the token stream looking for numbers, tagging each
+// with a role (from a preceding keyword) and a unit (from a trailing
+// asset/currency word) where it can find one.
+func scanNumbers(tokens []string) []match {
+	var matches []match
+
+	for i := 0; i < len(tokens); {
+		value, consumed, confidence, ok := parseNumber(tokens, i)
+		if !ok {
+			i++
+			continue
+		}
+
+		m := match{value: value, confidence: confidence}
+
+		if i > 0 {
+			switch {
+			case priceKeywords[tokens[i-1]]:
+				m.role = rolePrice
+			case quantityKeywords[tokens[i-1]]:
+				m.role = roleQuantity
+			}
+		}
+
+		if next := i + consumed; next < len(tokens) {
+			if unit, isAsset := assetUnits[tokens[next]]; isAsset {
+				m.unit = unit
+				if m.role == roleUnknown {
+					m.role = roleQuantity
+				}
+				consumed++
+			} else if unit, isCurrency := currencyUnits[tokens[next]]; isCurrency {
+				m.unit = unit
+				if m.role == roleUnknown {
+					m.role = rolePrice
+				}
+				consumed++
+			}
+		}
+
+		if m.role == roleUnknown {
+			// Nothing in the sentence told us which field this belongs to;
+			// flag it as less certain so the FSM can ask the user to confirm.
+			m.confidence -= 0.2
+		}
+
+		matches = append(matches, m)
+		i += consumed
+	}
+
+	return matches
+}
+
+// ExtractFirst returns the first number found in text, regardless of
+// role. Use it where the caller already knows what the number means,
+// e.g. a follow-up question that only asks for a quantity.
+func ExtractFirst(text string) (Result, bool) {
+	matches := scanNumbers(tokenize(text))
+	if len(matches) == 0 {
+		return Result{}, false
+	}
+	m := matches[0]
+	return Result{Value: m.value, Unit: m.unit, Confidence: m.confidence}, true
+}
+
+// ExtractOrderDetails scans text for a quantity and/or a price,
+// disambiguating which is which using positional keywords ("at"/"for"/"@"
+// introduce a price) and attached units (an asset name implies quantity,
+// a currency name implies price). A number with neither is assigned to
+// whichever field is still unfilled, in the order it appeared.
+func ExtractOrderDetails(text string) (quantity, price Result, hasQuantity, hasPrice bool) {
+	for _, m := range scanNumbers(tokenize(text)) {
+		r := Result{Value: m.value, Unit: m.unit, Confidence: m.confidence}
+
+		switch {
+		case m.role == rolePrice && !hasPrice:
+			price, hasPrice = r, true
+		case m.role == roleQuantity && !hasQuantity:
+			quantity, hasQuantity = r, true
+		case m.role == roleUnknown && !hasQuantity:
+			quantity, hasQuantity = r, true
+		case m.role == roleUnknown && !hasPrice:
+			price, hasPrice = r, true
+		}
+	}
+	return
+}
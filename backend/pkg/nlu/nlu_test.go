@@ -0,0 +1,71 @@
+package nlu
+
+import "testing"
+
+func TestExtractOrderDetails(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantQuantity float64
+		hasQuantity  bool
+		wantPrice    float64
+		hasPrice     bool
+	}{
+		{
+			name:         "quantity and price with keyword and unit",
+			text:         "buy 1.5 bitcoin at 65000 dollars",
+			wantQuantity: 1.5,
+			hasQuantity:  true,
+			wantPrice:    65000,
+			hasPrice:     true,
+		},
+		{
+			name:         "digit run folds into a following scale word",
+			text:         "1.5 bitcoin for 65 thousand dollars",
+			wantQuantity: 1.5,
+			hasQuantity:  true,
+			wantPrice:    65000,
+			hasPrice:     true,
+		},
+		{
+			name:         "suffixed shorthand",
+			text:         "0.5 btc at 65k",
+			wantQuantity: 0.5,
+			hasQuantity:  true,
+			wantPrice:    65000,
+			hasPrice:     true,
+		},
+		{
+			name:         "spoken fraction",
+			text:         "half a bitcoin for sixty five thousand dollars",
+			wantQuantity: 0.5,
+			hasQuantity:  true,
+			wantPrice:    65000,
+			hasPrice:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quantity, price, hasQuantity, hasPrice := ExtractOrderDetails(tt.text)
+			if hasQuantity != tt.hasQuantity || quantity.Value != tt.wantQuantity {
+				t.Errorf("quantity = %+v, hasQuantity = %v, want value %v, hasQuantity %v", quantity, hasQuantity, tt.wantQuantity, tt.hasQuantity)
+			}
+			if hasPrice != tt.hasPrice || price.Value != tt.wantPrice {
+				t.Errorf("price = %+v, hasPrice = %v, want value %v, hasPrice %v", price, hasPrice, tt.wantPrice, tt.hasPrice)
+			}
+		})
+	}
+}
+
+func TestParseNumberDigitScaleWord(t *testing.T) {
+	// Regression test: "65 thousand" used to be parsed as two separate
+	// numbers (65, then 1000) instead of one (65000).
+	result, ok := ExtractFirst("65 thousand")
+	if !ok {
+		t.Fatal("ExtractFirst() returned ok = false")
+	}
+	if result.Value != 65000 {
+		t.Errorf("ExtractFirst(\"65 thousand\").Value = %v, want 65000", result.Value)
+	}
+}
@@ -0,0 +1,285 @@
+// Package sqlite is the default, dev-friendly store.Store implementation,
+// backed by a single on-disk SQLite file via the pure-Go modernc.org/sqlite
+// driver (no cgo toolchain required).
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS sessions (
+	call_id           TEXT PRIMARY KEY,
+	state             TEXT NOT NULL,
+	exchange          TEXT NOT NULL,
+	symbol            TEXT NOT NULL,
+	side              TEXT NOT NULL,
+	price             REAL NOT NULL,
+	quantity          REAL NOT NULL,
+	order_price       REAL NOT NULL,
+	exchange_order_id TEXT NOT NULL,
+	created_at        DATETIME NOT NULL,
+	updated_at        DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	call_id           TEXT NOT NULL,
+	exchange          TEXT NOT NULL,
+	symbol            TEXT NOT NULL,
+	side              TEXT NOT NULL,
+	price             REAL NOT NULL,
+	quantity          REAL NOT NULL,
+	order_id          TEXT NOT NULL,
+	parent_order_id   TEXT NOT NULL DEFAULT '',
+	state             TEXT NOT NULL,
+	timestamp         DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_orders_call_id ON orders(call_id);
+CREATE INDEX IF NOT EXISTS idx_orders_parent_order_id ON orders(parent_order_id);
+
+CREATE TABLE IF NOT EXISTS halts (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	scope      TEXT NOT NULL,
+	exchange   TEXT NOT NULL DEFAULT '',
+	symbol     TEXT NOT NULL DEFAULT '',
+	reason     TEXT NOT NULL,
+	until      DATETIME,
+	created_at DATETIME NOT NULL,
+	cleared_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_halts_active ON halts(cleared_at);
+
+CREATE TABLE IF NOT EXISTS credentials (
+	call_id    TEXT NOT NULL,
+	exchange   TEXT NOT NULL,
+	ciphertext BLOB NOT NULL,
+	updated_at DATETIME NOT NULL,
+	PRIMARY KEY (call_id, exchange)
+);
+`
+
+// Store is a SQLite-backed store.Store.
+type Store struct {
+	db *sql.DB
+}
+
+// New opens (and migrates) a SQLite database at path, e.g. "./quant.db".
+func New(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: open: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite: migrate: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) SaveSession(ctx context.Context, sess store.Session) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO sessions (call_id, state, exchange, symbol, side, price, quantity, order_price, exchange_order_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(call_id) DO UPDATE SET
+			state = excluded.state,
+			exchange = excluded.exchange,
+			symbol = excluded.symbol,
+			side = excluded.side,
+			price = excluded.price,
+			quantity = excluded.quantity,
+			order_price = excluded.order_price,
+			exchange_order_id = excluded.exchange_order_id,
+			updated_at = excluded.updated_at
+	`, sess.CallID, sess.State, sess.Exchange, sess.Symbol, sess.Side, sess.Price, sess.Quantity, sess.OrderPrice, sess.ExchangeOrderID, sess.CreatedAt, sess.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("sqlite: save session: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) LoadSession(ctx context.Context, callID string) (store.Session, error) {
+	var sess store.Session
+	row := s.db.QueryRowContext(ctx, `
+		SELECT call_id, state, exchange, symbol, side, price, quantity, order_price, exchange_order_id, created_at, updated_at
+		FROM sessions WHERE call_id = ?
+	`, callID)
+	err := row.Scan(&sess.CallID, &sess.State, &sess.Exchange, &sess.Symbol, &sess.Side, &sess.Price, &sess.Quantity, &sess.OrderPrice, &sess.ExchangeOrderID, &sess.CreatedAt, &sess.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return store.Session{}, store.ErrSessionNotFound
+	}
+	if err != nil {
+		return store.Session{}, fmt.Errorf("sqlite: load session: %w", err)
+	}
+	return sess, nil
+}
+
+func (s *Store) AppendOrder(ctx context.Context, order store.Order) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO orders (call_id, exchange, symbol, side, price, quantity, order_id, parent_order_id, state, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, order.CallID, order.Exchange, order.Symbol, order.Side, order.Price, order.Quantity, order.OrderID, order.ParentOrderID, order.State, order.Timestamp)
+	if err != nil {
+		return fmt.Errorf("sqlite: append order: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) ListOrders(ctx context.Context, callID string, filter store.OrderFilter) ([]store.Order, error) {
+	query := `SELECT id, call_id, exchange, symbol, side, price, quantity, order_id, parent_order_id, state, timestamp FROM orders WHERE call_id = ?`
+	args := []interface{}{callID}
+
+	if filter.State != "" {
+		query += " AND state = ?"
+		args = append(args, filter.State)
+	}
+	if filter.ParentOrderID != "" {
+		query += " AND parent_order_id = ?"
+		args = append(args, filter.ParentOrderID)
+	}
+	if !filter.From.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		query += " AND timestamp <= ?"
+		args = append(args, filter.To)
+	}
+	query += " ORDER BY timestamp DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []store.Order
+	for rows.Next() {
+		var o store.Order
+		if err := rows.Scan(&o.ID, &o.CallID, &o.Exchange, &o.Symbol, &o.Side, &o.Price, &o.Quantity, &o.OrderID, &o.ParentOrderID, &o.State, &o.Timestamp); err != nil {
+			return nil, fmt.Errorf("sqlite: scan order: %w", err)
+		}
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+// nullTime adapts a possibly-zero time.Time for a nullable DATETIME
+// column: the zero value (Go's "no time set") is stored as SQL NULL.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (s *Store) SaveHalt(ctx context.Context, h store.Halt) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO halts (scope, exchange, symbol, reason, until, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, h.Scope, h.Exchange, h.Symbol, h.Reason, nullTime(h.Until), h.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: save halt: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: save halt: %w", err)
+	}
+	return id, nil
+}
+
+func (s *Store) ListActiveHalts(ctx context.Context, asOf time.Time) ([]store.Halt, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, scope, exchange, symbol, reason, until, created_at
+		FROM halts
+		WHERE cleared_at IS NULL AND (until IS NULL OR until > ?)
+	`, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list active halts: %w", err)
+	}
+	defer rows.Close()
+
+	var halts []store.Halt
+	for rows.Next() {
+		var h store.Halt
+		var until sql.NullTime
+		if err := rows.Scan(&h.ID, &h.Scope, &h.Exchange, &h.Symbol, &h.Reason, &until, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan halt: %w", err)
+		}
+		h.Until = until.Time
+		halts = append(halts, h)
+	}
+	return halts, rows.Err()
+}
+
+func (s *Store) ClearHalt(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE halts SET cleared_at = ? WHERE id = ?`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("sqlite: clear halt: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) SaveCredential(ctx context.Context, cred store.Credential) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO credentials (call_id, exchange, ciphertext, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(call_id, exchange) DO UPDATE SET
+			ciphertext = excluded.ciphertext,
+			updated_at = excluded.updated_at
+	`, cred.CallID, cred.Exchange, cred.Ciphertext, cred.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("sqlite: save credential: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) LoadCredential(ctx context.Context, callID, exchangeName string) (store.Credential, error) {
+	var cred store.Credential
+	row := s.db.QueryRowContext(ctx, `
+		SELECT call_id, exchange, ciphertext, updated_at
+		FROM credentials WHERE call_id = ? AND exchange = ?
+	`, callID, exchangeName)
+	err := row.Scan(&cred.CallID, &cred.Exchange, &cred.Ciphertext, &cred.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return store.Credential{}, store.ErrCredentialNotFound
+	}
+	if err != nil {
+		return store.Credential{}, fmt.Errorf("sqlite: load credential: %w", err)
+	}
+	return cred, nil
+}
+
+func (s *Store) DeleteCredential(ctx context.Context, callID, exchangeName string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM credentials WHERE call_id = ? AND exchange = ?`, callID, exchangeName)
+	if err != nil {
+		return fmt.Errorf("sqlite: delete credential: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) ReapExpired(ctx context.Context, olderThan time.Time) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE state = 'completed' AND updated_at < ?`, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: reap expired: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: reap expired: %w", err)
+	}
+	return int(n), nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,147 @@
+// Package store persists trading sessions and order history so
+// conversation state and the order blotter survive a backend restart and
+// can be shared across multiple backend instances.
+package store
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrSessionNotFound is returned by LoadSession when no session has been
+// saved for the given CallID.
+var ErrSessionNotFound = errors.New("store: session not found")
+
+// ErrCredentialNotFound is returned by LoadCredential when no credential
+// has been saved for the given CallID/exchange.
+var ErrCredentialNotFound = errors.New("store: credential not found")
+
+// Session is the persisted form of a conversation's trading state.
+type Session struct {
+	CallID          string
+	State           string
+	Exchange        string
+	Symbol          string
+	Side            string
+	Price           float64
+	Quantity        float64
+	OrderPrice      float64
+	ExchangeOrderID string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Order is one recorded state transition of a placed order. Implementations
+// append a new row per transition rather than mutating one in place, so
+// ListOrders can reconstruct an order's full history.
+type Order struct {
+	ID            int64
+	CallID        string
+	Exchange      string
+	Symbol        string
+	Side          string
+	Price         float64
+	Quantity      float64
+	OrderID       string // exchange-assigned order ID
+	ParentOrderID string // set for child orders sliced off a smart-execution parent
+	State         string // working, filled, cancelled, rejected
+	Timestamp     time.Time
+}
+
+// Halt is a persisted trading halt blocking new order placement, at
+// global, per-exchange, or per-symbol scope (see pkg/halts), until a
+// wall-clock time or until manually cleared.
+type Halt struct {
+	ID        int64
+	Scope     string // "global", "exchange", or "symbol"
+	Exchange  string // set for exchange/symbol scope
+	Symbol    string // set for symbol scope
+	Reason    string
+	Until     time.Time // zero means in effect until manually cleared
+	CreatedAt time.Time
+	ClearedAt time.Time // zero means still in effect
+}
+
+// Credential is a persisted, already-encrypted per-session/exchange API
+// credential blob (see pkg/vault). Keying it by CallID (the Session key)
+// plus Exchange lets it survive a restart alongside the session it
+// belongs to; the plaintext key material never reaches this package.
+type Credential struct {
+	CallID     string
+	Exchange   string
+	Ciphertext []byte
+	UpdatedAt  time.Time
+}
+
+// OrderFilter narrows a ListOrders call, mirroring the optional-parameter
+// filtering style used by the exchange subpackages.
+type OrderFilter struct {
+	State         string // empty means any state
+	ParentOrderID string // empty means any parent (or none)
+	From          time.Time
+	To            time.Time
+	Limit         int // 0 means no limit
+}
+
+// Store is the persistence surface the handlers package depends on.
+// SQLite (pkg/store/sqlite) is the default/dev implementation; Postgres
+// (pkg/store/postgres) is available for multi-instance deployments.
+type Store interface {
+	SaveSession(ctx context.Context, session Session) error
+	LoadSession(ctx context.Context, callID string) (Session, error)
+	AppendOrder(ctx context.Context, order Order) error
+	ListOrders(ctx context.Context, callID string, filter OrderFilter) ([]Order, error)
+
+	// SaveHalt persists a new halt, returning its assigned ID.
+	SaveHalt(ctx context.Context, halt Halt) (int64, error)
+	// ListActiveHalts returns every halt not yet cleared whose Until is
+	// either zero (indefinite) or still in the future as of asOf.
+	ListActiveHalts(ctx context.Context, asOf time.Time) ([]Halt, error)
+	// ClearHalt marks a halt cleared so it no longer blocks placement.
+	ClearHalt(ctx context.Context, id int64) error
+
+	// SaveCredential upserts the encrypted credential blob for
+	// cred.CallID/cred.Exchange.
+	SaveCredential(ctx context.Context, cred Credential) error
+	// LoadCredential returns the encrypted credential blob for
+	// callID/exchangeName, or ErrCredentialNotFound if none is stored.
+	LoadCredential(ctx context.Context, callID, exchangeName string) (Credential, error)
+	// DeleteCredential removes any stored credential for callID/exchangeName.
+	DeleteCredential(ctx context.Context, callID, exchangeName string) error
+
+	// ReapExpired deletes sessions in the "completed" state whose
+	// UpdatedAt is older than olderThan, returning the count removed.
+	ReapExpired(ctx context.Context, olderThan time.Time) (int, error)
+
+	Close() error
+}
+
+// StartReaper runs ReapExpired on a ticker until stop() is called. ttl is
+// how long a completed session is kept before it's reaped.
+func StartReaper(s Store, ttl, interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				n, err := s.ReapExpired(context.Background(), time.Now().Add(-ttl))
+				if err != nil {
+					log.Printf("store: reap failed: %v", err)
+					continue
+				}
+				if n > 0 {
+					log.Printf("store: reaped %d expired session(s)", n)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,164 @@
+// Package vault encrypts per-session exchange API credentials at rest.
+// Credentials are cached in memory keyed by CallID and exchange, with only the
+// ciphertext held after Put returns; the server-side key never leaves
+// this package. When constructed with a store.Store, the ciphertext is
+// also persisted there so credentials survive a backend restart, the
+// same as the session/order state in pkg/store.
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/store"
+)
+
+// Credentials is the superset of fields any supported venue's API key
+// might need. Venues that don't use a passphrase (Binance, Bybit, Deribit)
+// simply leave it empty.
+type Credentials struct {
+	APIKey     string `json:"api_key"`
+	APISecret  string `json:"api_secret"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// ErrNotFound is returned by Get when no credentials are stored for a CallID/exchange pair.
+var ErrNotFound = errors.New("vault: no credentials stored")
+
+// Vault holds encrypted per-session, per-exchange credentials in memory,
+// optionally persisting the ciphertext through st.
+type Vault struct {
+	key   [32]byte
+	st    store.Store // nil disables persistence; the in-memory cache still works
+	mu    sync.RWMutex
+	cache map[string][]byte // "<callID>:<exchange>" -> ciphertext
+}
+
+// New returns a Vault that encrypts with serverKey. serverKey must be 32
+// bytes (AES-256); callers typically derive it from an env var such as
+// VAULT_ENCRYPTION_KEY. st may be nil to run memory-only, e.g. in tests;
+// passing the backend's session store makes credentials survive a
+// restart the same way sessions and orders do.
+func New(serverKey [32]byte, st store.Store) *Vault {
+	return &Vault{key: serverKey, st: st, cache: make(map[string][]byte)}
+}
+
+func vaultKey(callID, exchangeName string) string {
+	return callID + ":" + exchangeName
+}
+
+// Put encrypts and stores creds for the given session/exchange pair,
+// overwriting any previous entry.
+func (v *Vault) Put(callID, exchangeName string, creds Credentials) error {
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(v.key[:])
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	v.mu.Lock()
+	v.cache[vaultKey(callID, exchangeName)] = ciphertext
+	v.mu.Unlock()
+
+	if v.st != nil {
+		err := v.st.SaveCredential(context.Background(), store.Credential{
+			CallID:     callID,
+			Exchange:   exchangeName,
+			Ciphertext: ciphertext,
+			UpdatedAt:  time.Now(),
+		})
+		if err != nil {
+			log.Printf("vault: failed to persist credential for %s/%s: %v", callID, exchangeName, err)
+		}
+	}
+	return nil
+}
+
+// Get decrypts and returns the credentials stored for callID/exchangeName,
+// falling back to the backing store (and repopulating the cache) on a
+// cache miss so a credential registered before a restart is still found.
+func (v *Vault) Get(callID, exchangeName string) (Credentials, error) {
+	key := vaultKey(callID, exchangeName)
+
+	v.mu.RLock()
+	ciphertext, ok := v.cache[key]
+	v.mu.RUnlock()
+
+	if !ok {
+		if v.st == nil {
+			return Credentials{}, ErrNotFound
+		}
+		cred, err := v.st.LoadCredential(context.Background(), callID, exchangeName)
+		if err == store.ErrCredentialNotFound {
+			return Credentials{}, ErrNotFound
+		}
+		if err != nil {
+			return Credentials{}, err
+		}
+		ciphertext = cred.Ciphertext
+		v.mu.Lock()
+		v.cache[key] = ciphertext
+		v.mu.Unlock()
+	}
+
+	block, err := aes.NewCipher(v.key[:])
+	if err != nil {
+		return Credentials{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return Credentials{}, errors.New("vault: malformed ciphertext")
+	}
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return Credentials{}, err
+	}
+	return creds, nil
+}
+
+// Delete removes any stored credentials for callID/exchangeName.
+func (v *Vault) Delete(callID, exchangeName string) {
+	v.mu.Lock()
+	delete(v.cache, vaultKey(callID, exchangeName))
+	v.mu.Unlock()
+
+	if v.st != nil {
+		if err := v.st.DeleteCredential(context.Background(), callID, exchangeName); err != nil {
+			log.Printf("vault: failed to delete persisted credential for %s/%s: %v", callID, exchangeName, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package wsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// BinanceAdapter streams Binance's combined bookTicker + depth20 streams.
+// Binance frames are plain JSON, unlike OKX/Bybit's gzip-compressed feeds.
+type BinanceAdapter struct{}
+
+func (BinanceAdapter) DialURL() string {
+	return "wss://stream.binance.com:9443/stream"
+}
+
+func (BinanceAdapter) SubscribeMessage(pair exchange.CurrencyPair) ([]byte, error) {
+	symbol := strings.ToLower(pair.ToSymbol(""))
+	return json.Marshal(map[string]interface{}{
+		"method": "SUBSCRIBE",
+		"params": []string{symbol + "@bookTicker", symbol + "@depth20@100ms"},
+		"id":     1,
+	})
+}
+
+func (BinanceAdapter) Decode(raw []byte) (*Quote, *DepthSnapshot, error) {
+	var msg struct {
+		Stream string          `json:"stream"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, nil, fmt.Errorf("binance: decode envelope: %w", err)
+	}
+
+	switch {
+	case strings.HasSuffix(msg.Stream, "@bookTicker"):
+		var row struct {
+			BidPrice string `json:"b"`
+			AskPrice string `json:"a"`
+		}
+		if err := json.Unmarshal(msg.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		bid, ask := parseFloat(row.BidPrice), parseFloat(row.AskPrice)
+		return &Quote{Bid: bid, Ask: ask, Last: (bid + ask) / 2}, nil, nil
+
+	case strings.Contains(msg.Stream, "@depth"):
+		var row struct {
+			Bids [][]string `json:"bids"`
+			Asks [][]string `json:"asks"`
+		}
+		if err := json.Unmarshal(msg.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		return nil, &DepthSnapshot{
+			Bids: toDepthRecords(row.Bids),
+			Asks: toDepthRecords(row.Asks),
+		}, nil
+
+	default:
+		return nil, nil, nil
+	}
+}
@@ -0,0 +1,75 @@
+package wsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// BybitAdapter streams Bybit's v5 public spot tickers + orderbook.50 channels.
+type BybitAdapter struct{}
+
+func (BybitAdapter) DialURL() string {
+	return "wss://stream.bybit.com/v5/public/spot"
+}
+
+func (BybitAdapter) SubscribeMessage(pair exchange.CurrencyPair) ([]byte, error) {
+	symbol := strings.ToUpper(pair.ToSymbol(""))
+	return json.Marshal(map[string]interface{}{
+		"op":   "subscribe",
+		"args": []string{"tickers." + symbol, "orderbook.50." + symbol},
+	})
+}
+
+func (BybitAdapter) Decode(raw []byte) (*Quote, *DepthSnapshot, error) {
+	raw, err := maybeGunzip(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bybit: gunzip: %w", err)
+	}
+
+	var msg struct {
+		Topic string          `json:"topic"`
+		TS    int64           `json:"ts"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, nil, fmt.Errorf("bybit: decode envelope: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Topic, "tickers."):
+		var row struct {
+			LastPrice string `json:"lastPrice"`
+			Bid1Price string `json:"bid1Price"`
+			Ask1Price string `json:"ask1Price"`
+		}
+		if err := json.Unmarshal(msg.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		return &Quote{
+			Last:      parseFloat(row.LastPrice),
+			Bid:       parseFloat(row.Bid1Price),
+			Ask:       parseFloat(row.Ask1Price),
+			Timestamp: msg.TS,
+		}, nil, nil
+
+	case strings.HasPrefix(msg.Topic, "orderbook."):
+		var row struct {
+			Bids [][]string `json:"b"`
+			Asks [][]string `json:"a"`
+		}
+		if err := json.Unmarshal(msg.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		return nil, &DepthSnapshot{
+			Bids:      toDepthRecords(row.Bids),
+			Asks:      toDepthRecords(row.Asks),
+			Timestamp: msg.TS,
+		}, nil
+
+	default:
+		return nil, nil, nil
+	}
+}
@@ -0,0 +1,96 @@
+package wsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// DeribitAdapter streams Deribit's JSON-RPC-over-WebSocket ticker and
+// order book channels for the instrument's perpetual.
+type DeribitAdapter struct{}
+
+func (DeribitAdapter) DialURL() string {
+	return "wss://www.deribit.com/ws/api/v2"
+}
+
+func instrumentName(pair exchange.CurrencyPair) string {
+	return strings.ToUpper(string(pair.Basis)) + "-PERPETUAL"
+}
+
+func (DeribitAdapter) SubscribeMessage(pair exchange.CurrencyPair) ([]byte, error) {
+	instrument := instrumentName(pair)
+	return json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "public/subscribe",
+		"params": map[string]interface{}{
+			"channels": []string{
+				"ticker." + instrument + ".100ms",
+				"book." + instrument + ".none.10.100ms",
+			},
+		},
+	})
+}
+
+func (DeribitAdapter) Decode(raw []byte) (*Quote, *DepthSnapshot, error) {
+	var msg struct {
+		Params struct {
+			Channel string          `json:"channel"`
+			Data    json.RawMessage `json:"data"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, nil, fmt.Errorf("deribit: decode envelope: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(msg.Params.Channel, "ticker."):
+		var row struct {
+			LastPrice    float64 `json:"last_price"`
+			BestBidPrice float64 `json:"best_bid_price"`
+			BestAskPrice float64 `json:"best_ask_price"`
+			Timestamp    int64   `json:"timestamp"`
+		}
+		if err := json.Unmarshal(msg.Params.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		return &Quote{
+			Last:      row.LastPrice,
+			Bid:       row.BestBidPrice,
+			Ask:       row.BestAskPrice,
+			Timestamp: row.Timestamp,
+		}, nil, nil
+
+	case strings.HasPrefix(msg.Params.Channel, "book."):
+		var row struct {
+			Bids      [][]float64 `json:"bids"`
+			Asks      [][]float64 `json:"asks"`
+			Timestamp int64       `json:"timestamp"`
+		}
+		if err := json.Unmarshal(msg.Params.Data, &row); err != nil {
+			return nil, nil, nil
+		}
+		return nil, &DepthSnapshot{
+			Bids:      toFloatDepthRecords(row.Bids),
+			Asks:      toFloatDepthRecords(row.Asks),
+			Timestamp: row.Timestamp,
+		}, nil
+
+	default:
+		return nil, nil, nil
+	}
+}
+
+func toFloatDepthRecords(rows [][]float64) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{Price: row[0], Amount: row[1]})
+	}
+	return records
+}
@@ -0,0 +1,80 @@
+package wsclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// OKXAdapter streams OKX's public tickers + books5 channels.
+type OKXAdapter struct{}
+
+func (OKXAdapter) DialURL() string {
+	return "wss://ws.okx.com:8443/ws/v5/public"
+}
+
+func (OKXAdapter) SubscribeMessage(pair exchange.CurrencyPair) ([]byte, error) {
+	instID := pair.ToSymbol("-")
+	return json.Marshal(map[string]interface{}{
+		"op": "subscribe",
+		"args": []map[string]string{
+			{"channel": "tickers", "instId": instID},
+			{"channel": "books5", "instId": instID},
+		},
+	})
+}
+
+func (OKXAdapter) Decode(raw []byte) (*Quote, *DepthSnapshot, error) {
+	raw, err := maybeGunzip(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("okx: gunzip: %w", err)
+	}
+
+	var msg struct {
+		Arg struct {
+			Channel string `json:"channel"`
+		} `json:"arg"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, nil, fmt.Errorf("okx: decode envelope: %w", err)
+	}
+
+	switch msg.Arg.Channel {
+	case "tickers":
+		var rows []struct {
+			Last  string `json:"last"`
+			BidPx string `json:"bidPx"`
+			AskPx string `json:"askPx"`
+			TS    string `json:"ts"`
+		}
+		if err := json.Unmarshal(msg.Data, &rows); err != nil || len(rows) == 0 {
+			return nil, nil, nil
+		}
+		return &Quote{
+			Last:      parseFloat(rows[0].Last),
+			Bid:       parseFloat(rows[0].BidPx),
+			Ask:       parseFloat(rows[0].AskPx),
+			Timestamp: parseInt(rows[0].TS),
+		}, nil, nil
+
+	case "books5":
+		var rows []struct {
+			Bids [][]string `json:"bids"`
+			Asks [][]string `json:"asks"`
+			TS   string     `json:"ts"`
+		}
+		if err := json.Unmarshal(msg.Data, &rows); err != nil || len(rows) == 0 {
+			return nil, nil, nil
+		}
+		return nil, &DepthSnapshot{
+			Bids:      toDepthRecords(rows[0].Bids),
+			Asks:      toDepthRecords(rows[0].Asks),
+			Timestamp: parseInt(rows[0].TS),
+		}, nil
+
+	default:
+		return nil, nil, nil
+	}
+}
@@ -0,0 +1,31 @@
+package wsclient
+
+import (
+	"strconv"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
+
+func toDepthRecords(rows [][]string) []exchange.DepthRecord {
+	records := make([]exchange.DepthRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		records = append(records, exchange.DepthRecord{
+			Price:  parseFloat(row[0]),
+			Amount: parseFloat(row[1]),
+		})
+	}
+	return records
+}
@@ -0,0 +1,271 @@
+// Package wsclient maintains live public WebSocket feeds (ticker + top-of-
+// book depth) for each supported exchange and fans normalized updates out
+// to however many sessions are watching the same symbol, so N sessions
+// subscribed to the same pair share one upstream connection.
+package wsclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/abhinavpinamdar/quantbot-backend/pkg/exchange"
+)
+
+// Quote is a normalized top-of-book snapshot pushed from an upstream feed.
+type Quote struct {
+	Bid       float64
+	Ask       float64
+	Last      float64
+	Timestamp int64
+}
+
+// DepthSnapshot is a normalized order-book update pushed from an upstream feed.
+type DepthSnapshot struct {
+	Bids      []exchange.DepthRecord
+	Asks      []exchange.DepthRecord
+	Timestamp int64
+}
+
+// Adapter translates one exchange's public WebSocket feed into the
+// normalized Quote/DepthSnapshot types. Implementations live in this
+// package, one <venue>_adapter.go file per exchange.
+type Adapter interface {
+	// DialURL is the venue's public WebSocket endpoint.
+	DialURL() string
+	// SubscribeMessage builds the frame(s) to send after connecting to
+	// subscribe to ticker + depth updates for pair.
+	SubscribeMessage(pair exchange.CurrencyPair) ([]byte, error)
+	// Decode parses one inbound frame, returning whichever of Quote/
+	// DepthSnapshot it carries (either may be nil).
+	Decode(raw []byte) (*Quote, *DepthSnapshot, error)
+}
+
+const (
+	initialBackoff = time.Second
+	maxBackoff     = 30 * time.Second
+	subscriberBuf  = 8
+)
+
+// subscription is one session's view onto an upstream feed.
+type subscription struct {
+	quotes chan Quote
+	depths chan DepthSnapshot
+}
+
+// upstream is a single shared WebSocket connection for one exchange+pair,
+// reconnecting with exponential backoff and fanning decoded messages out
+// to every subscriber.
+type upstream struct {
+	adapter Adapter
+	pair    exchange.CurrencyPair
+
+	mu          sync.Mutex
+	subscribers map[int]*subscription
+	nextID      int
+	stop        chan struct{}
+}
+
+func newUpstream(adapter Adapter, pair exchange.CurrencyPair) *upstream {
+	u := &upstream{
+		adapter:     adapter,
+		pair:        pair,
+		subscribers: make(map[int]*subscription),
+		stop:        make(chan struct{}),
+	}
+	go u.run()
+	return u
+}
+
+func (u *upstream) run() {
+	backoff := initialBackoff
+	for {
+		select {
+		case <-u.stop:
+			return
+		default:
+		}
+
+		if err := u.connectOnce(&backoff); err != nil {
+			log.Printf("wsclient: %s connection ended: %v (retrying in %s)", u.pair, err, backoff)
+		}
+
+		select {
+		case <-u.stop:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (u *upstream) connectOnce(backoff *time.Duration) error {
+	conn, _, err := websocket.DefaultDialer.Dial(u.adapter.DialURL(), nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	sub, err := u.adapter.SubscribeMessage(u.pair)
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, sub); err != nil {
+		return err
+	}
+
+	// A clean connection resets the backoff for the *next* disconnect.
+	*backoff = initialBackoff
+
+	for {
+		select {
+		case <-u.stop:
+			return nil
+		default:
+		}
+
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		quote, depth, err := u.adapter.Decode(raw)
+		if err != nil {
+			log.Printf("wsclient: decode error for %s: %v", u.pair, err)
+			continue
+		}
+		u.broadcast(quote, depth)
+	}
+}
+
+func (u *upstream) broadcast(quote *Quote, depth *DepthSnapshot) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, sub := range u.subscribers {
+		if quote != nil {
+			select {
+			case sub.quotes <- *quote:
+			default: // slow consumer; drop rather than block the shared feed
+			}
+		}
+		if depth != nil {
+			select {
+			case sub.depths <- *depth:
+			default:
+			}
+		}
+	}
+}
+
+func (u *upstream) subscribe() (int, *subscription) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	id := u.nextID
+	u.nextID++
+	sub := &subscription{
+		quotes: make(chan Quote, subscriberBuf),
+		depths: make(chan DepthSnapshot, subscriberBuf),
+	}
+	u.subscribers[id] = sub
+	return id, sub
+}
+
+func (u *upstream) unsubscribe(id int) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.subscribers, id)
+	return len(u.subscribers) == 0
+}
+
+func (u *upstream) close() {
+	close(u.stop)
+}
+
+// Multiplexer dedups upstream connections: multiple sessions subscribed
+// to the same exchange+pair share a single upstream WebSocket.
+type Multiplexer struct {
+	mu        sync.Mutex
+	adapters  map[string]Adapter
+	upstreams map[string]*upstream
+}
+
+// NewMultiplexer returns a Multiplexer dispatching to adapters keyed by
+// exchange name (e.g. "OKX", "Binance").
+func NewMultiplexer(adapters map[string]Adapter) *Multiplexer {
+	return &Multiplexer{
+		adapters:  adapters,
+		upstreams: make(map[string]*upstream),
+	}
+}
+
+func upstreamKey(exchangeName string, pair exchange.CurrencyPair) string {
+	return exchangeName + ":" + pair.String()
+}
+
+// Subscribe returns channels of normalized quotes/depth for exchangeName's
+// feed of pair, opening a shared upstream connection if none exists yet.
+// Call the returned unsubscribe func when the caller is done.
+func (m *Multiplexer) Subscribe(exchangeName string, pair exchange.CurrencyPair) (<-chan Quote, <-chan DepthSnapshot, func(), error) {
+	adapter, ok := m.adapters[exchangeName]
+	if !ok {
+		return nil, nil, nil, errUnsupportedExchange(exchangeName)
+	}
+
+	key := upstreamKey(exchangeName, pair)
+
+	// up.subscribe() happens under the same m.mu critical section as the
+	// lookup/create, and unsubscribe's emptiness-check-and-delete
+	// below also holds m.mu throughout. That shared lock is what prevents
+	// a subscribe landing on an upstream that's concurrently being torn
+	// down: the two can never interleave between "found empty" and
+	// "deleted and closed".
+	m.mu.Lock()
+	up, exists := m.upstreams[key]
+	if !exists {
+		up = newUpstream(adapter, pair)
+		m.upstreams[key] = up
+	}
+	id, sub := up.subscribe()
+	m.mu.Unlock()
+
+	unsubscribe := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if up.unsubscribe(id) && m.upstreams[key] == up {
+			delete(m.upstreams, key)
+			up.close()
+		}
+	}
+
+	return sub.quotes, sub.depths, unsubscribe, nil
+}
+
+type errUnsupportedExchange string
+
+func (e errUnsupportedExchange) Error() string {
+	return "wsclient: no adapter registered for exchange " + string(e)
+}
+
+// maybeGunzip transparently decompresses gzip-framed messages (the
+// convention OKX and Huobi-style feeds use); frames that aren't gzip are
+// returned unchanged.
+func maybeGunzip(raw []byte) ([]byte, error) {
+	if len(raw) < 2 || raw[0] != 0x1f || raw[1] != 0x8b {
+		return raw, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}